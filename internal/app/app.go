@@ -9,6 +9,7 @@ import (
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/config"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
 	matchesR "github.com/DarkhanShakhan/telegram-bot-template/internal/repository/matches"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/service/match"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -75,12 +76,12 @@ func (a *App) initDB() error {
 
 func (a *App) initService() error {
 	repository := matchesR.New(a.pool)
-	a.service = match.New(repository)
+	a.service = match.New(repository, view.TeamPalette)
 	return nil
 }
 
 func (a *App) initTelegramBot() error {
-	server, err := telegram.New(a.config.TelegramToken, a.cache, a.usersCache, a.service)
+	server, err := telegram.New(a.config.TelegramToken, a.cache, a.usersCache, a.service, a.config.AdminIDs, a.config.Webhook)
 	if err != nil {
 		return err
 	}
@@ -90,5 +91,8 @@ func (a *App) initTelegramBot() error {
 
 func (a *App) Start() {
 	log.Println("starting telegram bot")
-	a.botServer.Start()
+	if err := a.botServer.Start(); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("telegram bot stopped")
 }
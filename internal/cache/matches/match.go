@@ -2,6 +2,7 @@ package matches
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
@@ -10,19 +11,21 @@ import (
 )
 
 type Cache interface {
-	SetMatch(username string)
-	SetSportType(username string, sportType enum.SportType) error
-	SetLocation(username string, location string) error
-	SetDay(username string, day enum.MatchDay) error
-	SetTime(username string, time time.Duration) error
-	SetDuration(username string, duration time.Duration) error
-	SetTeamSize(username string, size int64) error
-	SetTeamCount(username string, count int64) error
-	SetRent(username string, rent int64) error
-	SetPrivate(username string, private bool) error
-	DeleteMatch(username string)
-	GetStatus(username string) (Status, error)
-	GetMatch(username string) (*entity.Match, error)
+	SetMatch(telegramID int64)
+	SetSportType(telegramID int64, sportType enum.SportType) error
+	SetLocation(telegramID int64, location string) error
+	SetVenue(telegramID int64, venue *entity.Venue) error
+	SetDay(telegramID int64, day enum.MatchDay) error
+	SetTime(telegramID int64, time time.Duration) error
+	SetDuration(telegramID int64, duration time.Duration) error
+	Reschedule(telegramID int64, startAt time.Time) error
+	SetTeamSize(telegramID int64, size int64) error
+	SetTeamCount(telegramID int64, count int64) error
+	SetRent(telegramID int64, rent int64) error
+	SetPrivate(telegramID int64, private bool) error
+	DeleteMatch(telegramID int64)
+	GetStatus(telegramID int64) (Status, error)
+	GetMatch(telegramID int64) (*entity.Match, error)
 }
 
 type Status int64
@@ -58,32 +61,47 @@ type match struct {
 	status Status
 }
 
-func (c *matchesCache) SetMatch(username string) {
-	c.cache.Set(c.prefix+username, &match{status: StatusNew}, 0)
+func (c *matchesCache) SetMatch(telegramID int64) {
+	c.cache.Set(c.key(telegramID), &match{status: StatusNew}, 0)
 
 }
-func (c *matchesCache) SetSportType(username string, sportType enum.SportType) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetSportType(telegramID int64, sportType enum.SportType) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.Type = sportType
 	m.status = StatusSportType
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) SetLocation(username string, location string) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetLocation(telegramID int64, location string) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.Location = location
 	m.status = StatusLocation
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) SetDay(username string, day enum.MatchDay) error {
-	m, err := c.getMatch(username)
+
+// SetVenue attaches a saved venue to the match, which also sets its location.
+func (c *matchesCache) SetVenue(telegramID int64, venue *entity.Venue) error {
+	m, err := c.getMatch(telegramID)
+	if err != nil {
+		return err
+	}
+	m.Match.Location = venue.Name
+	m.Match.VenueID = &venue.ID
+	m.Match.Venue = venue
+	m.status = StatusLocation
+	c.cache.Set(c.key(telegramID), m, 0)
+	return nil
+}
+
+func (c *matchesCache) SetDay(telegramID int64, day enum.MatchDay) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
@@ -98,72 +116,84 @@ func (c *matchesCache) SetDay(username string, day enum.MatchDay) error {
 	return nil
 }
 
-func (c *matchesCache) SetTime(username string, startTime time.Duration) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetTime(telegramID int64, startTime time.Duration) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.StartAt = m.Match.StartAt.Add(startTime)
 	m.status = StatusStartTime
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) SetDuration(username string, duration time.Duration) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetDuration(telegramID int64, duration time.Duration) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.FinishAt = m.Match.StartAt.Add(duration)
 	m.status = StatusDuration
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
 
-func (c *matchesCache) SetTeamSize(username string, size int64) error {
-	m, err := c.getMatch(username)
+// Reschedule moves the match to startAt keeping its duration and status.
+func (c *matchesCache) Reschedule(telegramID int64, startAt time.Time) error {
+	m, err := c.getMatch(telegramID)
+	if err != nil {
+		return err
+	}
+	duration := m.Match.FinishAt.Sub(m.Match.StartAt)
+	m.Match.StartAt, m.Match.FinishAt = startAt, startAt.Add(duration)
+	c.cache.Set(c.key(telegramID), m, 0)
+	return nil
+}
+
+func (c *matchesCache) SetTeamSize(telegramID int64, size int64) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.TeamSize = size
 	m.status = StatusTeamSize
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) SetTeamCount(username string, count int64) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetTeamCount(telegramID int64, count int64) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.TeamCount = count
 	m.status = StatusTeamCount
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) SetRent(username string, rent int64) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetRent(telegramID int64, rent int64) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.Rent = rent
 	m.status = StatusRent
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) SetPrivate(username string, private bool) error {
-	m, err := c.getMatch(username)
+func (c *matchesCache) SetPrivate(telegramID int64, private bool) error {
+	m, err := c.getMatch(telegramID)
 	if err != nil {
 		return err
 	}
 	m.Match.IsPrivate = private
 	m.status = StatusPrivate
-	c.cache.Set(c.prefix+username, m, 0)
+	c.cache.Set(c.key(telegramID), m, 0)
 	return nil
 }
-func (c *matchesCache) DeleteMatch(username string) {
-	c.cache.Delete(c.prefix + username)
+func (c *matchesCache) DeleteMatch(telegramID int64) {
+	c.cache.Delete(c.key(telegramID))
 }
-func (c *matchesCache) GetStatus(username string) (Status, error) {
-	v, ok := c.cache.Get(c.prefix + username)
+func (c *matchesCache) GetStatus(telegramID int64) (Status, error) {
+	v, ok := c.cache.Get(c.key(telegramID))
 	if !ok {
 		return 0, errors.New("cannot get value")
 	}
@@ -173,8 +203,8 @@ func (c *matchesCache) GetStatus(username string) (Status, error) {
 	}
 	return m.status, nil
 }
-func (c *matchesCache) GetMatch(username string) (*entity.Match, error) {
-	v, ok := c.cache.Get(c.prefix + username)
+func (c *matchesCache) GetMatch(telegramID int64) (*entity.Match, error) {
+	v, ok := c.cache.Get(c.key(telegramID))
 	if !ok {
 		return nil, errors.New("cannot get value")
 	}
@@ -185,8 +215,8 @@ func (c *matchesCache) GetMatch(username string) (*entity.Match, error) {
 	return &m.Match, nil
 }
 
-func (c *matchesCache) getMatch(username string) (*match, error) {
-	v, ok := c.cache.Get(c.prefix + username)
+func (c *matchesCache) getMatch(telegramID int64) (*match, error) {
+	v, ok := c.cache.Get(c.key(telegramID))
 	if !ok {
 		return nil, errors.New("cannot get value")
 	}
@@ -196,3 +226,7 @@ func (c *matchesCache) getMatch(username string) (*match, error) {
 	}
 	return m, nil
 }
+
+func (c *matchesCache) key(telegramID int64) string {
+	return c.prefix + strconv.FormatInt(telegramID, 10)
+}
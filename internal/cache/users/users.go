@@ -1,8 +1,13 @@
 package users
 
 import (
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
 	"github.com/patrickmn/go-cache"
 )
 
@@ -11,28 +16,67 @@ type Status int64
 const (
 	StatusAddTeamMembers Status = iota + 1
 	StatusSendReport
+	StatusFilterLocation
+	StatusFilterNear
+	StatusVenueLocation
+	StatusVenuePhoto
+	StatusMatchScore
+	StatusSpotTransfer
+	StatusTeamName
+	StatusExpenseAmount
+	StatusFixedPrice
+	StatusBirthYear
+	StatusMatchChat
 )
 
 type User struct {
-	Username string
-	ChatID   int64
-	MatchID  int64
-	TeamID   int64
-	Status   Status
+	TelegramID int64
+	ChatID     int64
+	MatchID    int64
+	TeamID     int64
+	VenueID    int64
+	// MediaGroupID is the album the user sent last, so that the bot answers
+	// an album once rather than once per file.
+	MediaGroupID string
+	// ExpenseCategory is the category of the expense the organizer is typing.
+	ExpenseCategory enum.ExpenseCategory
+	Language        i18n.Lang
+	Status          Status
+	Search          Search
+}
+
+// Search is the state of the player's match search: the filters chosen on
+// the keyboard and the period, which is turned into a date range on every
+// query so that "today" keeps meaning today.
+type Search struct {
+	Filter entity.MatchFilter
+	Period string
 }
 
 type Cache interface {
-	SetTeamID(username string, teamID int64)
-	SetMatchID(username string, matchID int64)
-	SetStatus(username string, status Status)
-	GetStatus(username string) Status
+	SetTeamID(telegramID int64, teamID int64)
+	SetMatchID(telegramID int64, matchID int64)
+	SetVenueID(telegramID int64, venueID int64)
+	// FirstOfMediaGroup records the album of the message and reports
+	// whether the message is the first one of it, or isn't in an album.
+	FirstOfMediaGroup(telegramID int64, mediaGroupID string) bool
+	SetExpenseCategory(telegramID int64, category enum.ExpenseCategory)
+	SetLanguage(telegramID int64, lang i18n.Lang)
+	GetLanguage(telegramID int64) i18n.Lang
+	SetStatus(telegramID int64, status Status)
+	GetStatus(telegramID int64) Status
 	SetUser(user User)
-	GetUser(username string) (*User, bool)
+	GetUser(telegramID int64) (*User, bool)
+	SetSearch(telegramID int64, search Search)
+	GetSearch(telegramID int64) Search
 }
 
 type userCache struct {
 	prefix string
 	cache  *cache.Cache
+	// mu makes checking and recording the album one step, since the files
+	// of an album are handled concurrently.
+	mu sync.Mutex
 }
 
 func New(prefix string, defaultDuration, cleanupInterval time.Duration) Cache {
@@ -42,38 +86,110 @@ func New(prefix string, defaultDuration, cleanupInterval time.Duration) Cache {
 	}
 }
 
-func (c *userCache) SetStatus(username string, status Status) {
-	u, ok := c.GetUser(username)
+func (c *userCache) SetStatus(telegramID int64, status Status) {
+	u, ok := c.GetUser(telegramID)
 	if !ok {
-		c.cache.Set(c.prefix+username, &User{Status: status}, 0)
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, Status: status}, 0)
 		return
 	}
 	u.Status = status
-	c.cache.Set(c.prefix+username, u, 0)
+	c.cache.Set(c.key(telegramID), u, 0)
 }
 
-func (c *userCache) SetTeamID(username string, teamID int64) {
-	u, ok := c.GetUser(username)
+func (c *userCache) SetTeamID(telegramID int64, teamID int64) {
+	u, ok := c.GetUser(telegramID)
 	if !ok {
-		c.cache.Set(c.prefix+username, &User{TeamID: teamID}, 0)
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, TeamID: teamID}, 0)
 		return
 	}
 	u.TeamID = teamID
-	c.cache.Set(c.prefix+username, u, 0)
+	c.cache.Set(c.key(telegramID), u, 0)
 }
 
-func (c *userCache) SetMatchID(username string, matchID int64) {
-	u, ok := c.GetUser(username)
+func (c *userCache) SetMatchID(telegramID int64, matchID int64) {
+	u, ok := c.GetUser(telegramID)
 	if !ok {
-		c.cache.Set(c.prefix+username, &User{MatchID: matchID}, 0)
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, MatchID: matchID}, 0)
 		return
 	}
 	u.MatchID = matchID
-	c.cache.Set(c.prefix+username, u, 0)
+	c.cache.Set(c.key(telegramID), u, 0)
+}
+
+func (c *userCache) SetSearch(telegramID int64, search Search) {
+	u, ok := c.GetUser(telegramID)
+	if !ok {
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, Search: search}, 0)
+		return
+	}
+	u.Search = search
+	c.cache.Set(c.key(telegramID), u, 0)
+}
+
+func (c *userCache) GetSearch(telegramID int64) Search {
+	u, ok := c.GetUser(telegramID)
+	if !ok {
+		return Search{}
+	}
+	return u.Search
+}
+
+func (c *userCache) SetVenueID(telegramID int64, venueID int64) {
+	u, ok := c.GetUser(telegramID)
+	if !ok {
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, VenueID: venueID}, 0)
+		return
+	}
+	u.VenueID = venueID
+	c.cache.Set(c.key(telegramID), u, 0)
+}
+
+func (c *userCache) FirstOfMediaGroup(telegramID int64, mediaGroupID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	u, ok := c.GetUser(telegramID)
+	if !ok {
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, MediaGroupID: mediaGroupID}, 0)
+		return true
+	}
+	if mediaGroupID != "" && u.MediaGroupID == mediaGroupID {
+		return false
+	}
+	u.MediaGroupID = mediaGroupID
+	c.cache.Set(c.key(telegramID), u, 0)
+	return true
+}
+
+func (c *userCache) SetExpenseCategory(telegramID int64, category enum.ExpenseCategory) {
+	u, ok := c.GetUser(telegramID)
+	if !ok {
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, ExpenseCategory: category}, 0)
+		return
+	}
+	u.ExpenseCategory = category
+	c.cache.Set(c.key(telegramID), u, 0)
+}
+
+func (c *userCache) SetLanguage(telegramID int64, lang i18n.Lang) {
+	u, ok := c.GetUser(telegramID)
+	if !ok {
+		c.cache.Set(c.key(telegramID), &User{TelegramID: telegramID, Language: lang}, 0)
+		return
+	}
+	u.Language = lang
+	c.cache.Set(c.key(telegramID), u, 0)
 }
 
-func (c *userCache) GetStatus(username string) Status {
-	u, ok := c.GetUser(username)
+func (c *userCache) GetLanguage(telegramID int64) i18n.Lang {
+	u, ok := c.GetUser(telegramID)
+	if !ok || u.Language == "" {
+		return i18n.Default
+	}
+	return u.Language
+}
+
+func (c *userCache) GetStatus(telegramID int64) Status {
+	u, ok := c.GetUser(telegramID)
 	if !ok {
 		return 0
 	}
@@ -81,14 +197,18 @@ func (c *userCache) GetStatus(username string) Status {
 }
 
 func (c *userCache) SetUser(user User) {
-	c.cache.Set(c.prefix+user.Username, &user, 0)
+	c.cache.Set(c.key(user.TelegramID), &user, 0)
 }
 
-func (c *userCache) GetUser(username string) (*User, bool) {
-	v, ok := c.cache.Get(c.prefix + username)
+func (c *userCache) GetUser(telegramID int64) (*User, bool) {
+	v, ok := c.cache.Get(c.key(telegramID))
 	if !ok {
 		return nil, false
 	}
 	u, ok := v.(*User)
 	return u, ok
 }
+
+func (c *userCache) key(telegramID int64) string {
+	return c.prefix + strconv.FormatInt(telegramID, 10)
+}
@@ -13,6 +13,28 @@ type Config struct {
 	AppEnv        string `yaml:"app_env" envconfig:"APP_ENV"`
 	TelegramToken string `yaml:"telegram_token" envconfig:"TELEGRAM_TOKEN"`
 	PostgresDSN   string `yaml:"postgres_dsn" envconfig:"POSTGRES_DSN"`
+	// AdminIDs are the Telegram IDs of the users allowed to manage sports.
+	AdminIDs []int64 `yaml:"admin_ids" envconfig:"ADMIN_IDS"`
+	Webhook  Webhook `yaml:"webhook" envconfig:"WEBHOOK"`
+}
+
+// Webhook makes Telegram post updates to the bot instead of the bot polling
+// for them. The bot polls while URL is empty.
+type Webhook struct {
+	// URL is the public address Telegram posts updates to, the bot serves
+	// its path.
+	URL string `yaml:"url" envconfig:"URL"`
+	// Listen is the address the webhook server listens on, a local one
+	// when a reverse proxy in front of the bot terminates TLS.
+	Listen string `yaml:"listen" envconfig:"LISTEN"`
+	// Secret is the token Telegram sends with every update to prove it is
+	// the sender.
+	Secret string `yaml:"secret" envconfig:"SECRET"`
+	// CertFile and KeyFile make the bot serve TLS itself.
+	CertFile string `yaml:"cert_file" envconfig:"CERT_FILE"`
+	KeyFile  string `yaml:"key_file" envconfig:"KEY_FILE"`
+	// SelfSigned uploads the certificate to Telegram so that it trusts it.
+	SelfSigned bool `yaml:"self_signed" envconfig:"SELF_SIGNED"`
 }
 
 func New() *Config {
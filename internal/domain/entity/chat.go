@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// ChatMessage is a message a player sent to everyone else in the match.
+type ChatMessage struct {
+	ID        int64     `db:"id"`
+	MatchID   int64     `db:"match_id"`
+	SenderID  int64     `db:"sender_id"`
+	ReplyToID *int64    `db:"reply_to_id"`
+	Text      string    `db:"text"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ChatCopy is the Telegram message a chat message was delivered as, kept
+// so that replies to it can be threaded for every recipient.
+type ChatCopy struct {
+	MessageID         int64 `db:"message_id"`
+	ChatID            int64 `db:"chat_id"`
+	TelegramMessageID int   `db:"telegram_message_id"`
+}
+
+// MatchChat is the Telegram group the organizer linked to the match. The
+// same group can be linked to every match of a recurring game.
+type MatchChat struct {
+	MatchID  int64     `db:"match_id"`
+	ChatID   int64     `db:"chat_id"`
+	Title    string    `db:"title"`
+	LinkedAt time.Time `db:"linked_at"`
+}
+
+// ChatInvite is the single-use link a player got to join the group of the
+// match, revoked when they leave the match.
+type ChatInvite struct {
+	MatchID    int64  `db:"match_id"`
+	UserID     int64  `db:"user_id"`
+	InviteLink string `db:"invite_link"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+)
+
+// Eligibility restricts who can sign up for a match. Nil bounds and the
+// empty gender mean no restriction. Levels are on the rating scale.
+type Eligibility struct {
+	MinLevel *int64      `db:"min_level"`
+	MaxLevel *int64      `db:"max_level"`
+	Gender   enum.Gender `db:"gender"`
+	MinAge   *int64      `db:"min_age"`
+	MaxAge   *int64      `db:"max_age"`
+}
+
+// Profile is what the eligibility rules are checked against.
+type Profile struct {
+	Gender    enum.Gender `db:"gender"`
+	BirthYear *int64      `db:"birth_year"`
+	// Level is the rating of the player in the sport of the match.
+	Level int64 `db:"level"`
+}
+
+// IsSet reports whether the match has any restriction.
+func (e *Eligibility) IsSet() bool {
+	return e.MinLevel != nil || e.MaxLevel != nil || e.Gender != enum.GenderAny || e.MinAge != nil || e.MaxAge != nil
+}
+
+// Unmet returns the first rule the player doesn't meet, or the empty rule.
+// Players who haven't told their gender or age don't meet rules on them.
+func (e *Eligibility) Unmet(p *Profile, now time.Time) enum.EligibilityRule {
+	if e.MinLevel != nil && p.Level < *e.MinLevel || e.MaxLevel != nil && p.Level > *e.MaxLevel {
+		return enum.EligibilityLevel
+	}
+	if e.Gender != enum.GenderAny && p.Gender != e.Gender {
+		return enum.EligibilityGender
+	}
+	if e.MinAge != nil || e.MaxAge != nil {
+		age, ok := p.Age(now)
+		if !ok || e.MinAge != nil && age < *e.MinAge || e.MaxAge != nil && age > *e.MaxAge {
+			return enum.EligibilityAge
+		}
+	}
+	return ""
+}
+
+// Age is the age the player turns this year, false when the birth year is
+// unknown.
+func (p *Profile) Age(now time.Time) (int64, bool) {
+	if p.BirthYear == nil {
+		return 0, false
+	}
+	return int64(now.Year()) - *p.BirthYear, true
+}
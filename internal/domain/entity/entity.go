@@ -1,99 +1,383 @@
 package entity
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
 )
 
 type Match struct {
-	ID                int64          `db:"id"`
-	Type              enum.SportType `db:"sport"`
-	OrganizerID       int64          `db:"organizer_id"`
-	OrganizerUsername string
-	Location          string    `db:"location"`
-	Rent              int64     `db:"rent"`
-	StartAt           time.Time `db:"start_at"`
-	FinishAt          time.Time `db:"finish_at"`
-	TeamSize          int64     `db:"team_size"`
-	TeamCount         int64     `db:"team_count"`
-	IsPrivate         bool      `db:"private"`
-	MembersCount      int64     `db:"members_count"`
-	Teams             []*Team
+	ID            int64          `db:"id"`
+	Type          enum.SportType `db:"sport"`
+	OrganizerID   int64          `db:"organizer_id"`
+	OrganizerName string
+	Location      string `db:"location"`
+	VenueID       *int64 `db:"venue_id"`
+	Venue         *Venue
+	Rent          int64     `db:"rent"`
+	StartAt       time.Time `db:"start_at"`
+	FinishAt      time.Time `db:"finish_at"`
+	TeamSize      int64     `db:"team_size"`
+	TeamCount     int64     `db:"team_count"`
+	IsPrivate     bool      `db:"private"`
+	MembersCount  int64     `db:"members_count"`
+	Distance      *float64  `db:"distance"`
+	// MinReliability is the reliability score, in percent, a player needs
+	// to sign up on their own. Nil means anyone can sign up.
+	MinReliability *int64 `db:"min_reliability"`
+	// CancelWindowHours is how many hours before the start players can
+	// still leave freely. Zero means until the very start.
+	CancelWindowHours int64             `db:"cancel_window_hours"`
+	PricingModel      enum.PricingModel `db:"pricing_model"`
+	FixedPrice        int64             `db:"fixed_price"`
+	// MembersDiscount is the sum of the discounts, in percent, of the
+	// players signed up. Only the search loads it, instead of the players.
+	MembersDiscount int64 `db:"members_discount"`
+	Eligibility
+	// Discounts maps players to their discount in percent.
+	Discounts map[int64]int64
+	Teams     []*Team
+	Sport     *Sport
+}
+
+// Venue is a pitch or hall matches are played at.
+type Venue struct {
+	ID             int64               `db:"id"`
+	Name           string              `db:"name"`
+	Address        string              `db:"address"`
+	Latitude       *float64            `db:"latitude"`
+	Longitude      *float64            `db:"longitude"`
+	Sports         []string            `db:"sports"`
+	DefaultRent    int64               `db:"default_rent"`
+	PhotoFileID    string              `db:"photo_file_id"`
+	CreatedBy      *int64              `db:"created_by"`
+	ConflictPolicy enum.ConflictPolicy `db:"conflict_policy"`
+}
+
+// HasLocation reports whether the venue has coordinates attached.
+func (v *Venue) HasLocation() bool {
+	return v.Latitude != nil && v.Longitude != nil
+}
+
+// OwnedBy reports whether the user added the venue. Only they may change
+// its location, photo, rent and policy.
+func (v *Venue) OwnedBy(userID int64) bool {
+	return v.CreatedBy != nil && *v.CreatedBy == userID
+}
+
+// VenueConflict describes the matches already booked at the venue during
+// the requested time and the nearest slots that are still free.
+type VenueConflict struct {
+	Policy    enum.ConflictPolicy
+	Matches   []*Match
+	FreeSlots []time.Time
+}
+
+// Blocking reports whether the venue forbids overlapping matches.
+func (c *VenueConflict) Blocking() bool {
+	return c.Policy == enum.ConflictPolicyBlock
+}
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// MatchFilter narrows down the open matches a player is looking for.
+// Zero values mean "any".
+type MatchFilter struct {
+	Sport         enum.SportType
+	From          time.Time
+	To            time.Time
+	MaxPrice      int64
+	MinFreePlaces int64
+	Location      string
+	Near          *GeoPoint
+	RadiusKm      float64
+	TeamSize      int64
+	Descending    bool
+	Limit         int
+	Offset        int
+	// Gender keeps the matches for the gender only.
+	Gender enum.Gender
+	// Eligible keeps the matches the player with the profile can join.
+	Eligible *Profile
 }
 
 type Team struct {
 	ID      int64  `db:"id"`
 	Name    string `db:"name"`
 	Size    int64  `db:"size"`
+	Emoji   string `db:"emoji"`
+	ClubID  *int64 `db:"club_id"`
+	Score   *int64 `db:"score"`
 	Members []*User
 }
 
+// TeamMove moves a player of a match from one of its teams to another.
+type TeamMove struct {
+	User       *User
+	FromTeamID int64
+	ToTeamID   int64
+}
+
+// TeamAssignment is a proposed distribution of the match players over its
+// teams together with the moves needed to get there.
+type TeamAssignment struct {
+	Teams []*Team
+	Moves []*TeamMove
+}
+
+// Club is a team name an organizer keeps to reuse across matches.
+type Club struct {
+	ID      int64  `db:"id"`
+	OwnerID int64  `db:"owner_id"`
+	Name    string `db:"name"`
+	Emoji   string `db:"emoji"`
+}
+
+// HasRoom reports whether the team has a free place.
+func (t *Team) HasRoom() bool {
+	return int64(len(t.Members)) < t.Size
+}
+
+// Team returns the team of the match with the given ID, or nil.
+func (m *Match) Team(teamID int64) *Team {
+	for _, t := range m.Teams {
+		if t.ID == teamID {
+			return t
+		}
+	}
+	return nil
+}
+
+// RatingSum is the total rating of the team members.
+func (t *Team) RatingSum() int64 {
+	var sum int64
+	for _, m := range t.Members {
+		sum += m.Rating
+	}
+	return sum
+}
+
 type User struct {
-	ID        int64  `db:"id"`
-	Name      string `db:"name"`
-	Username  string `db:"username"`
-	ChatID    int    `db:"chat_id"`
-	Confirmed bool   `db:"confirmed"`
-	Paid      bool   `db:"paid"`
-	Cancelled bool   `db:"cancelled"`
-}
-
-func (u *User) String() string {
-	out := "@" + u.Username
-	sign := "⚪️"
-	if u.Confirmed {
-		sign = "🟡"
+	ID         int64  `db:"id"`
+	TelegramID int64  `db:"telegram_id"`
+	Name       string `db:"name"`
+	Username   string `db:"username"`
+	ChatID     int64  `db:"chat_id"`
+	Confirmed  bool   `db:"confirmed"`
+	Paid       bool   `db:"paid"`
+	Cancelled  bool   `db:"cancelled"`
+	Rating     int64  `db:"rating"`
+	Goals      int64  `db:"goals"`
+	MVP        bool   `db:"mvp"`
+	Attended   *bool  `db:"attended"`
+	// PaidAmount is what the player owed when they marked the match as
+	// paid.
+	PaidAmount int64 `db:"paid_amount"`
+	// Language is the language the user talks to the bot in.
+	Language string `db:"language"`
+	// Unreachable is why the bot can't write to the user, empty while it
+	// can.
+	Unreachable enum.UnreachableReason `db:"unreachable"`
+}
+
+// Cancellation is a player leaving a match. Leaving after the cancel
+// deadline without a replacement makes the player liable for their share.
+type Cancellation struct {
+	MatchID      int64  `db:"match_id"`
+	UserID       int64  `db:"user_id"`
+	Late         bool   `db:"late"`
+	LiableAmount int64  `db:"liable_amount"`
+	ReplacedBy   *int64 `db:"replaced_by"`
+}
+
+// Report is what the organizer shares with the players after a match: a
+// text and any number of photos, videos and documents. It stays a draft
+// until it is published.
+type Report struct {
+	ID          int64     `db:"id"`
+	MatchID     int64     `db:"match_id"`
+	AuthorID    int64     `db:"author_id"`
+	Text        string    `db:"text"`
+	Published   bool      `db:"published"`
+	CreatedAt   time.Time `db:"created_at"`
+	Attachments []*Attachment
+}
+
+type Attachment struct {
+	ID       int64               `db:"id"`
+	ReportID int64               `db:"report_id"`
+	Kind     enum.AttachmentKind `db:"kind"`
+	FileID   string              `db:"file_id"`
+}
+
+type Expense struct {
+	ID       int64                `db:"id"`
+	MatchID  int64                `db:"match_id"`
+	Category enum.ExpenseCategory `db:"category"`
+	Amount   int64                `db:"amount"`
+}
+
+// CostSplit divides the actual cost of a match between the players who
+// played it. Recorded rent replaces the rent announced for the match.
+type CostSplit struct {
+	Match    *Match
+	Expenses []*Expense
+	Total    int64
+	Balances []*PlayerBalance
+}
+
+// PlayerBalance is what a player paid compared to their share. A positive
+// balance is owed to the player, a negative one is owed by them.
+type PlayerBalance struct {
+	User    *User
+	Share   int64
+	Paid    int64
+	Balance int64
+}
+
+// Discount is a reduction of the price a player pays for a match. A 100%
+// discount exempts the player.
+type Discount struct {
+	MatchID int64 `db:"match_id"`
+	UserID  int64 `db:"user_id"`
+	Percent int64 `db:"percent"`
+}
+
+// SpotTransfer is an offer to take over a player's spot in a match.
+type SpotTransfer struct {
+	ID         int64               `db:"id"`
+	MatchID    int64               `db:"match_id"`
+	FromUserID int64               `db:"from_user_id"`
+	ToUserID   int64               `db:"to_user_id"`
+	Status     enum.TransferStatus `db:"status"`
+}
+
+// Reliability is the attendance history of a player.
+type Reliability struct {
+	Attended    int64 `db:"attended"`
+	NoShows     int64 `db:"no_shows"`
+	LateCancels int64 `db:"late_cancels"`
+}
+
+// Score is the share of kept commitments in percent. Confirming and not
+// showing up and cancelling late both count as broken ones. Players without
+// any history score 100.
+func (r *Reliability) Score() int64 {
+	total := r.Attended + r.NoShows + r.LateCancels
+	if total == 0 {
+		return 100
+	}
+	return r.Attended * 100 / total
+}
+
+// PlayerStats sums up the recorded results of a player in one sport.
+type PlayerStats struct {
+	Sport  enum.SportType `db:"sport"`
+	Played int64          `db:"played"`
+	Wins   int64          `db:"wins"`
+	Draws  int64          `db:"draws"`
+	Losses int64          `db:"losses"`
+	Goals  int64          `db:"goals"`
+	MVP    int64          `db:"mvp"`
+}
+
+// Invitee is someone an organizer wants to add to a team. It is identified
+// either by Telegram ID (forwarded message, shared contact, text mention)
+// or by username (@mention).
+type Invitee struct {
+	TelegramID int64
+	Username   string
+	Name       string
+}
+
+// Invitation is a pending invite of a user who has not started the bot yet.
+// It is claimed as soon as someone with that username talks to the bot.
+type Invitation struct {
+	ID              int64  `db:"id"`
+	TeamID          int64  `db:"team_id"`
+	MatchID         int64  `db:"match_id"`
+	Username        string `db:"username"`
+	InvitedBy       int64  `db:"invited_by"`
+	InviterChatID   int64  `db:"inviter_chat_id"`
+	InviterLanguage string `db:"inviter_language"`
+}
+
+// InviteResult tells the organizer what happened to each invitee.
+type InviteResult struct {
+	Added     []*User
+	Pending   []string
+	Invalid   []string
+	Full      []string
+	AlreadyIn []string
+}
+
+// DisplayName returns @username when the user has one and the first name otherwise.
+func (u *User) DisplayName() string {
+	if u.Username != "" {
+		return "@" + u.Username
 	}
-	if u.Paid {
-		sign = "🟢"
+	return u.Name
+}
+
+// CancelDeadline is the last moment a player can leave without a penalty.
+func (m *Match) CancelDeadline() time.Time {
+	return m.StartAt.Add(-time.Duration(m.CancelWindowHours) * time.Hour)
+}
+
+// LateCancel reports whether the player leaving at now breaks a commitment:
+// only a confirmed spot given up after the cancel deadline does. Declining
+// an invitation never confirmed is not a cancellation.
+func (m *Match) LateCancel(userID int64, now time.Time) bool {
+	if !now.After(m.CancelDeadline()) {
+		return false
 	}
-	return sign + " " + out + " "
-}
-
-func (m *Match) String() string {
-	out := fmt.Sprintf(
-		`📢 Игра #%d
-	🏆 Спорт: %s
-	📍 %s
-	👤 Организатор: @%s
-	💰 С человека по %dтг
-	🗓 Дата матча: %d/%d
-	🕖 Начало матча: %d:00 (%.1f часа)
-	👥 Формат: %dvs%d (%d команды)
-
-	`,
-		m.ID, m.Type, m.Location, m.OrganizerUsername, m.Rent/(m.TeamCount*m.TeamSize), m.StartAt.Day(), m.StartAt.Month(), m.StartAt.Hour(), float64(m.FinishAt.Sub(m.StartAt).Minutes())/60.0,
-		m.TeamSize, m.TeamSize, m.TeamCount,
-	)
-	for _, team := range m.Teams {
-		team.Size = m.TeamSize
-		out += team.String()
+	for _, player := range m.Players() {
+		if player.ID == userID {
+			return player.Confirmed
+		}
 	}
-	out += fmt.Sprintf(`
-	🏃‍♂️Осталось %d мест 
-	`, m.places())
-	return out
+	return false
 }
 
-func (m *Match) places() int64 {
-	total := m.TeamCount * m.TeamSize
+// Players are the members of all teams of the match.
+func (m *Match) Players() []*User {
+	var players []*User
 	for _, t := range m.Teams {
-		total -= int64(len(t.Members))
+		players = append(players, t.Members...)
 	}
-	return total
+	return players
 }
 
-func (t *Team) String() string {
-	out := fmt.Sprintf(`%s %d / %d :(`, color[t.Name], len(t.Members), t.Size)
-	for _, m := range t.Members {
-		out += m.String()
+// TeamOf returns the team the user plays in, or nil.
+func (m *Match) TeamOf(userID int64) *Team {
+	for _, t := range m.Teams {
+		for _, member := range t.Members {
+			if member.ID == userID {
+				return t
+			}
+		}
 	}
-	out += `)
+	return nil
+}
 
-	`
-	return out
+// HasResult reports whether the organizer has recorded the score.
+func (m *Match) HasResult() bool {
+	for _, t := range m.Teams {
+		if t.Score != nil {
+			return true
+		}
+	}
+	return false
 }
 
-var color = map[string]string{"red": "🟥", "blue": "🟦", "green": "🟩", "yellow": "🟨", "purple": "🟪", "black": "⬛️", "brown": "🟫"}
+// PlacesLeft is the number of free places in the teams of the match.
+func (m *Match) PlacesLeft() int64 {
+	total := m.TeamCount * m.TeamSize
+	for _, t := range m.Teams {
+		total -= int64(len(t.Members))
+	}
+	return total
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/samber/lo"
+)
+
+// DefaultUTCOffset is the UTC offset, in hours, of users who didn't choose
+// theirs: the time of Kazakhstan, where most players are.
+const DefaultUTCOffset = 5
+
+// NotificationSettings are the user's choices on what the bot tells them
+// about and when. The zero value delivers everything right away.
+type NotificationSettings struct {
+	UserID int64 `db:"user_id"`
+	// Muted are the kinds of notifications the user doesn't want.
+	Muted []string `db:"muted"`
+	// Digest holds notifications to deliver them together.
+	Digest bool `db:"digest"`
+	// QuietFrom and QuietTo are the hours notifications are held during,
+	// QuietTo being excluded. The range may wrap around midnight.
+	QuietFrom *int64 `db:"quiet_from"`
+	QuietTo   *int64 `db:"quiet_to"`
+	// UTCOffset is the user's offset from UTC in hours, the quiet hours
+	// are in their local time.
+	UTCOffset int64 `db:"utc_offset"`
+}
+
+// Enabled reports whether the user wants notifications of the kind.
+func (s *NotificationSettings) Enabled(kind enum.NotificationKind) bool {
+	return !lo.Contains(s.Muted, string(kind))
+}
+
+// Quiet reports whether the moment falls into the user's quiet hours, in
+// the user's local time.
+func (s *NotificationSettings) Quiet(now time.Time) bool {
+	if s.QuietFrom == nil || s.QuietTo == nil {
+		return false
+	}
+	local := now.UTC().Add(time.Duration(s.UTCOffset) * time.Hour)
+	hour, from, to := int64(local.Hour()), *s.QuietFrom, *s.QuietTo
+	if from <= to {
+		return hour >= from && hour < to
+	}
+	return hour >= from || hour < to
+}
+
+// PendingNotification is a notification held for a digest or until the
+// quiet hours of the user are over.
+type PendingNotification struct {
+	ID        int64                 `db:"id"`
+	UserID    int64                 `db:"user_id"`
+	Kind      enum.NotificationKind `db:"kind"`
+	Text      string                `db:"text"`
+	ParseMode string                `db:"parse_mode"`
+	CreatedAt time.Time             `db:"created_at"`
+	// ReplyMarkup is the inline keyboard of the message as JSON, so that
+	// its buttons still work when it is delivered.
+	ReplyMarkup []byte `db:"reply_markup"`
+}
+
+// Digest is the notifications due to be delivered to the user together.
+type Digest struct {
+	User          *User
+	Notifications []*PendingNotification
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuiet(t *testing.T) {
+	hour := func(h int64) *int64 { return &h }
+	at := func(h int) time.Time { return time.Date(2023, time.July, 20, h, 30, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		from, to *int64
+		offset   int64
+		now      time.Time
+		want     bool
+	}{
+		{name: "no quiet hours", now: at(3)},
+		{name: "only a start", from: hour(22), now: at(23)},
+		{name: "inside", from: hour(13), to: hour(15), now: at(14), want: true},
+		{name: "at the start", from: hour(13), to: hour(15), now: at(13), want: true},
+		{name: "at the end", from: hour(13), to: hour(15), now: at(15)},
+		{name: "before", from: hour(13), to: hour(15), now: at(12)},
+		{name: "wrapping, before midnight", from: hour(22), to: hour(8), now: at(23), want: true},
+		{name: "wrapping, after midnight", from: hour(22), to: hour(8), now: at(2), want: true},
+		{name: "wrapping, at the end", from: hour(22), to: hour(8), now: at(8)},
+		{name: "wrapping, during the day", from: hour(22), to: hour(8), now: at(14)},
+		{name: "offset moves into the night", from: hour(22), to: hour(8), offset: 5, now: at(18), want: true},
+		{name: "offset moves out of the night", from: hour(22), to: hour(8), offset: 5, now: at(3)},
+		{name: "negative offset", from: hour(22), to: hour(8), offset: -4, now: at(2), want: true},
+		{name: "other zone of the moment", from: hour(22), to: hour(8), offset: 5,
+			now: time.Date(2023, time.July, 20, 23, 0, 0, 0, time.FixedZone("", 5*3600)), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &NotificationSettings{QuietFrom: tt.from, QuietTo: tt.to, UTCOffset: tt.offset}
+			if got := s.Quiet(tt.now); got != tt.want {
+				t.Errorf("Quiet(%s) = %t, want %t", tt.now, got, tt.want)
+			}
+		})
+	}
+}
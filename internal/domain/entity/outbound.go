@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+)
+
+// OutboundMessage is the delivery of a notification to one recipient.
+type OutboundMessage struct {
+	ID       int64                 `db:"id"`
+	ChatID   int64                 `db:"chat_id"`
+	Kind     enum.NotificationKind `db:"kind"`
+	Status   enum.DeliveryStatus   `db:"status"`
+	Attempts int64                 `db:"attempts"`
+	// Error is what Telegram answered to the last failed attempt.
+	Error string `db:"error"`
+	// Payload is the request as JSON, a message or an album, kept while it
+	// is pending so that it can be sent again.
+	Payload           []byte     `db:"payload"`
+	TelegramMessageID *int64     `db:"telegram_message_id"`
+	NextAttemptAt     *time.Time `db:"next_attempt_at"`
+	CreatedAt         time.Time  `db:"created_at"`
+}
@@ -0,0 +1,126 @@
+package entity
+
+import "github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+
+const fullPrice = 100
+
+// newcomerID stands for a player who hasn't signed up, no user has it.
+const newcomerID = 0
+
+// BasePrice is what a player without a discount pays.
+func (m *Match) BasePrice() int64 {
+	switch m.PricingModel {
+	case enum.PricingModelFree:
+		return 0
+	case enum.PricingModelFixed:
+		return m.FixedPrice
+	case enum.PricingModelSignedUp, enum.PricingModelAttended:
+		count := m.playersCount()
+		if m.PricingModel == enum.PricingModelAttended && m.Teams != nil {
+			count = int64(len(m.Attendees()))
+		}
+		if count == 0 {
+			count = 1
+		}
+		return ceilDiv(m.Rent, count)
+	default:
+		return ceilDiv(m.Rent, m.TeamCount*m.TeamSize)
+	}
+}
+
+// PriceFor is what the player pays with their discount applied. When the
+// rent is split between players, the discounts of some are covered by the
+// others.
+func (m *Match) PriceFor(userID int64) int64 {
+	switch m.PricingModel {
+	case enum.PricingModelSignedUp:
+		return m.SplitCost(m.Rent, m.withPlayer(m.Players(), userID))[userID]
+	case enum.PricingModelAttended:
+		return m.SplitCost(m.Rent, m.withPlayer(m.Attendees(), userID))[userID]
+	default:
+		return ceilDiv(m.BasePrice()*(fullPrice-m.Discount(userID)), fullPrice)
+	}
+}
+
+// JoinPrice is what a player without a discount pays on joining the match.
+// It is the same as PriceFor a newcomer, and is also known for the matches
+// of the search, which come with MembersCount and MembersDiscount instead
+// of their players.
+func (m *Match) JoinPrice() int64 {
+	split := m.PricingModel == enum.PricingModelSignedUp || m.PricingModel == enum.PricingModelAttended
+	if m.Teams == nil && split {
+		// the search only finds upcoming matches, where nobody is marked
+		// present yet and everyone signed up shares the rent
+		return share(m.Rent, 0, fullPrice*(m.MembersCount+1)-m.MembersDiscount)
+	}
+	return m.PriceFor(newcomerID)
+}
+
+// Discount is the discount of the player in percent.
+func (m *Match) Discount(userID int64) int64 {
+	return m.Discounts[userID]
+}
+
+// SplitCost divides the total between the players in proportion to the
+// part of the price each of them pays, rounding every share up.
+func (m *Match) SplitCost(total int64, players []*User) map[int64]int64 {
+	var weights int64
+	for _, p := range players {
+		weights += fullPrice - m.Discount(p.ID)
+	}
+	shares := make(map[int64]int64, len(players))
+	for _, p := range players {
+		if weights == 0 {
+			shares[p.ID] = 0
+			continue
+		}
+		shares[p.ID] = share(total, m.Discount(p.ID), weights)
+	}
+	return shares
+}
+
+// Attendees are the players marked as present, or everyone signed up while
+// attendance has not been marked yet.
+func (m *Match) Attendees() []*User {
+	var attendees []*User
+	for _, p := range m.Players() {
+		if p.Attended != nil && *p.Attended {
+			attendees = append(attendees, p)
+		}
+	}
+	if len(attendees) == 0 {
+		return m.Players()
+	}
+	return attendees
+}
+
+func (m *Match) playersCount() int64 {
+	if m.Teams == nil {
+		return m.MembersCount
+	}
+	return int64(len(m.Players()))
+}
+
+// withPlayer adds the user to the players when they are not among them yet,
+// to quote the price a newcomer would pay.
+func (m *Match) withPlayer(players []*User, userID int64) []*User {
+	for _, p := range players {
+		if p.ID == userID {
+			return players
+		}
+	}
+	return append(players, &User{ID: userID})
+}
+
+// share is the part of the total paid by a player with the discount, out of
+// the weights of all players, rounded up.
+func share(total, discount, weights int64) int64 {
+	return ceilDiv(total*(fullPrice-discount), weights)
+}
+
+func ceilDiv(a, b int64) int64 {
+	if b <= 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+)
+
+// TestJoinPriceOfSearch checks that the price of a match found by the
+// search, which only has the counts of its players, is the price a newcomer
+// is quoted once the match is loaded with its players.
+func TestJoinPriceOfSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		model     enum.PricingModel
+		players   int
+		discounts map[int64]int64
+	}{
+		{name: "capacity", model: enum.PricingModelCapacity, players: 3},
+		{name: "fixed", model: enum.PricingModelFixed, players: 3},
+		{name: "free", model: enum.PricingModelFree, players: 3},
+		{name: "signed up, nobody yet", model: enum.PricingModelSignedUp},
+		{name: "signed up", model: enum.PricingModelSignedUp, players: 3},
+		{name: "signed up with discounts", model: enum.PricingModelSignedUp, players: 4,
+			discounts: map[int64]int64{1: 50, 3: 100}},
+		{name: "attended with discounts", model: enum.PricingModelAttended, players: 2,
+			discounts: map[int64]int64{2: 25}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := &Team{ID: 1, Size: 5}
+			var discount int64
+			for i := 1; i <= tt.players; i++ {
+				team.Members = append(team.Members, &User{ID: int64(i)})
+				discount += tt.discounts[int64(i)]
+			}
+			loaded := &Match{
+				Rent: 10000, TeamSize: 5, TeamCount: 2, PricingModel: tt.model, FixedPrice: 1500,
+				Discounts: tt.discounts, Teams: []*Team{team},
+			}
+			found := &Match{
+				Rent: 10000, TeamSize: 5, TeamCount: 2, PricingModel: tt.model, FixedPrice: 1500,
+				MembersCount: int64(tt.players), MembersDiscount: discount,
+			}
+			want := loaded.PriceFor(newcomerID)
+			if got := found.JoinPrice(); got != want {
+				t.Errorf("JoinPrice() of the search = %d, PriceFor(newcomer) = %d", got, want)
+			}
+			if got := loaded.JoinPrice(); got != want {
+				t.Errorf("JoinPrice() of the loaded match = %d, PriceFor(newcomer) = %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+
+// Sport is a sport matches can be organized in along with the defaults
+// offered when creating a match.
+type Sport struct {
+	Code  enum.SportType `db:"code"`
+	Emoji string         `db:"emoji"`
+	// Names maps language codes to the name of the sport.
+	Names           map[string]string `db:"names"`
+	TeamSizes       []int64           `db:"team_sizes"`
+	DurationMinutes int64             `db:"duration_minutes"`
+	TeamCount       int64             `db:"team_count"`
+	Position        int64             `db:"position"`
+}
@@ -1,5 +1,7 @@
 package enum
 
+// SportType is the code of a sport in the sports table. The constants are
+// the sports the table is seeded with, more are added with "/sport".
 type SportType string
 
 const (
@@ -14,3 +16,136 @@ const (
 	MatchDayToday    MatchDay = "today"
 	MatchDayTomorrow MatchDay = "tomorrow"
 )
+
+// ConflictPolicy tells what happens when a match overlaps another one at the same venue.
+type ConflictPolicy string
+
+const (
+	ConflictPolicyWarn  ConflictPolicy = "warn"
+	ConflictPolicyBlock ConflictPolicy = "block"
+)
+
+// TransferStatus is the state of an offer to take over a player's spot.
+type TransferStatus string
+
+const (
+	TransferStatusPending  TransferStatus = "pending"
+	TransferStatusAccepted TransferStatus = "accepted"
+	TransferStatusDeclined TransferStatus = "declined"
+)
+
+// AttachmentKind is the type of a file attached to a match report.
+type AttachmentKind string
+
+const (
+	AttachmentKindPhoto    AttachmentKind = "photo"
+	AttachmentKindVideo    AttachmentKind = "video"
+	AttachmentKindDocument AttachmentKind = "document"
+)
+
+// ExpenseCategory is what the organizer spent money on for a match.
+type ExpenseCategory string
+
+const (
+	ExpenseCategoryRent    ExpenseCategory = "rent"
+	ExpenseCategoryBalls   ExpenseCategory = "balls"
+	ExpenseCategoryWater   ExpenseCategory = "water"
+	ExpenseCategoryReferee ExpenseCategory = "referee"
+	ExpenseCategoryOther   ExpenseCategory = "other"
+)
+
+// PricingModel tells how the price a player pays for a match is computed.
+type PricingModel string
+
+const (
+	// PricingModelCapacity splits the rent between all places of the match.
+	PricingModelCapacity PricingModel = "capacity"
+	// PricingModelFixed charges every player the same fixed price.
+	PricingModelFixed PricingModel = "fixed"
+	// PricingModelSignedUp splits the rent between the players signed up.
+	PricingModelSignedUp PricingModel = "signed_up"
+	// PricingModelAttended splits the rent between the players who came.
+	PricingModelAttended PricingModel = "attended"
+	PricingModelFree     PricingModel = "free"
+)
+
+// Gender is the gender of a player, or the gender a match is for. The
+// empty gender is unknown for players and anyone for matches.
+type Gender string
+
+const (
+	GenderAny    Gender = ""
+	GenderFemale Gender = "female"
+	GenderMale   Gender = "male"
+)
+
+// EligibilityRule names a restriction on who can sign up for a match.
+type EligibilityRule string
+
+const (
+	EligibilityLevel  EligibilityRule = "level"
+	EligibilityGender EligibilityRule = "gender"
+	EligibilityAge    EligibilityRule = "age"
+)
+
+// NotificationKind groups the messages the bot sends a user about what
+// others did, so that each group can be turned off.
+type NotificationKind string
+
+const (
+	// NotificationSignUp tells the organizer a player signed up.
+	NotificationSignUp NotificationKind = "signup"
+	// NotificationSignOut tells the organizer a player left or handed their
+	// place over.
+	NotificationSignOut NotificationKind = "signout"
+	NotificationConfirm NotificationKind = "confirm"
+	// NotificationPayment covers payments to the organizer and the cost
+	// shares sent to players.
+	NotificationPayment NotificationKind = "payment"
+	// NotificationMatch covers changes to a match the user is in: it being
+	// cancelled, rescheduled or rebalanced and its result.
+	NotificationMatch NotificationKind = "match"
+	// NotificationInvitation covers invitations, spot transfers and team
+	// switch requests.
+	NotificationInvitation NotificationKind = "invitation"
+	NotificationReport     NotificationKind = "report"
+	NotificationChat       NotificationKind = "chat"
+)
+
+// NotificationKinds in the order they are listed in the settings.
+var NotificationKinds = []NotificationKind{
+	NotificationSignUp,
+	NotificationSignOut,
+	NotificationConfirm,
+	NotificationPayment,
+	NotificationMatch,
+	NotificationInvitation,
+	NotificationReport,
+	NotificationChat,
+}
+
+// DeliveryStatus is the state of a notification sent to a recipient.
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending is a message Telegram didn't take yet that
+	// will be sent again.
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	// DeliveryStatusFailed is a message that was refused or ran out of
+	// attempts.
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// UnreachableReason is why the bot can't write to a user. The user is
+// reachable again as soon as they write to the bot.
+type UnreachableReason string
+
+const (
+	UnreachableBlocked UnreachableReason = "blocked"
+	// UnreachableChatNotFound is a user who never started the bot or
+	// deleted their chat with it.
+	UnreachableChatNotFound UnreachableReason = "chat_not_found"
+	// UnreachableDeactivated is a user who deleted their account.
+	UnreachableDeactivated UnreachableReason = "deactivated"
+)
@@ -10,6 +10,9 @@ type ErrorType uint
 
 const (
 	NoType = ErrorType(iota)
+	Conflict
+	Invalid
+	Forbidden
 )
 
 type customError struct {
@@ -58,6 +61,11 @@ func Newf(msg string, args ...any) error {
 	}
 }
 
+// Is reports whether any error in err's chain matches target.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
 func Cause(err error) error {
 	return errors.Cause(err)
 }
@@ -0,0 +1,388 @@
+package i18n
+
+var en = map[string]string{
+	"language.name": "English",
+	"language.ask":  "🌐 Choose your language",
+	"language.set":  "🌐 I speak English now",
+
+	"sport.ask":     "Choose a sport",
+	"sport.list":    "Sports:",
+	"sport.usage":   "Add or change a sport:\n/sport code emoji team_sizes minutes teams Название|Атауы|Name\nFor example: /sport padel 🎾 2 90 2 Падел|Падел|Padel",
+	"sport.saved":   "✅ Saved: %s",
+	"sport.invalid": "⚠️ %s",
+
+	"day.today":    "today",
+	"day.tomorrow": "tomorrow",
+
+	"duration.hours": "%.1f hours",
+
+	"card.title":     "Game #%d",
+	"card.sport":     "Sport",
+	"card.organizer": "Organizer",
+	"card.date":      "Match date",
+	"card.start":     "Kick-off",
+	"card.format":    "Format",
+
+	"match.teams":         "%d team|%d teams",
+	"match.score":         "🏁 Score: %s",
+	"match.places_left":   "🏃‍♂️%d place left|🏃‍♂️%d places left",
+	"match.you_cancelled": "You cancelled the match",
+	"match.cancelled":     "Match #%d is cancelled",
+	"match.fee_refunded":  "Your fee for match #%d has been refunded",
+
+	"matches.mine":      "🔜 Your upcoming matches",
+	"matches.organized": "🔜 Matches you organize",
+	"matches.none":      "😥 Sorry, there are no matches",
+
+	"price.free":       "Free",
+	"price.per_player": "%dtg per player",
+	"price.for_you":    "🎟 For you: %dtg",
+
+	"button.match_more":      "Match details",
+	"button.add_members":     "Add players",
+	"button.cancel_match":    "Cancel match",
+	"button.send_report":     "Send report",
+	"button.reschedule":      "🕖 Reschedule",
+	"button.expenses":        "💰 Expenses",
+	"button.ratings":         "⭐ Player levels",
+	"button.balance":         "⚖️ Balance teams",
+	"button.move_members":    "🔀 Move players",
+	"button.teams":           "🎨 Teams",
+	"button.roster":          "📋 Roster",
+	"button.min_reliability": "🛡 Reliability threshold",
+	"button.cancel_window":   "⏳ Sign-out deadline",
+	"button.eligibility":     "🎯 Who can play",
+	"button.chat":            "💬 Chat",
+	"button.group":           "👥 Group",
+	"button.pricing":         "🏷 Price",
+	"button.discounts":       "🎟 Discounts",
+	"button.result":          "📝 Result",
+	"button.reports":         "🖼 Reports",
+	"button.signup":          "Sign up",
+	"button.signout":         "Sign out",
+	"button.transfer":        "🔁 Give away my place",
+	"button.switch_team":     "🔀 Switch team",
+	"button.confirm":         "Confirm attendance",
+	"button.pay":             "Pay the fee",
+
+	"payment.done":      "You paid the fee",
+	"payment.organizer": "%s paid the fee for match %d",
+	"confirm.done":      "You confirmed your attendance",
+	"confirm.organizer": "%s confirmed attendance for match %d",
+
+	"create.ask_time":       "What time is the match?",
+	"create.ask_duration":   "How long is the match?",
+	"create.ask_team_size":  "How many players per team?",
+	"create.ask_team_count": "How many teams?",
+	"create.public":         "Public",
+	"create.private":        "Private",
+	"create.organize":       "Organize",
+	"create.cancel":         "Cancel",
+
+	"members.choose_team": "Add to team:",
+	"members.ask": "Send the usernames of the players you want to add, " +
+		"separated by spaces and starting with \"@\", or forward their message or contact",
+	"invitation.received": "You are invited to a match",
+	"invitation.claimed":  "%s joined the bot and was added to match #%d",
+	"invite.nobody":       "Couldn't find any players in the message",
+	"invite.added":        "✅ Added: %s",
+	"invite.pending":      "⏳ Waiting for them to start the bot: %s",
+	"invite.invalid":      "❌ Couldn't invite: %s",
+	"invite.already_in":   "👌 Already in the roster: %s",
+	"invite.filled":       "🚫 The team filled up in the meantime, nobody was added. Open the match to see the free places",
+	"invite.full":         "🚫 No places left in the team: %s",
+
+	"report.ask": "Send the match report: text, photos, videos or documents",
+
+	"money": "%dtg",
+
+	"venue.ask":             "Where is the match?\n\tChoose a venue, send the name of a new one or share a location",
+	"venue.ask_name":        "Send the venue name or a location",
+	"venues.none":           "You haven't added any venues yet",
+	"venue.rent":            "💰 Rent: %dtg",
+	"venue.set_location":    "📍 Set location",
+	"venue.change_location": "📍 Change location",
+	"venue.photo":           "🖼 Photo",
+	"venue.ask_location":    "Send the venue location",
+	"venue.ask_photo":       "Send a photo of the venue",
+	"venue.location_saved":  "Venue location saved",
+	"venue.photo_saved":     "Venue photo saved",
+	"location.ask_attach":   "Send a location via 📎",
+	"location.share":        "📍 Share location",
+
+	"create.ask_day":     "What day is the match?",
+	"create.ask_rent":    "How much is the rent?",
+	"create.ask_private": "Private or public match?",
+
+	"search.ask_near": "Share your location to find matches nearby",
+	"search.near":     "📍 Looking for matches near you",
+
+	"create.ask_confirm":     "Organize the match?",
+	"create.organize_anyway": "Organize anyway",
+
+	"reschedule.no_slots": "😥 No free time at the venue nearby",
+	"reschedule.ask":      "When should match #%d be moved to?",
+	"reschedule.busy":     "⛔️ The venue is already booked at that time",
+	"reschedule.done":     "🕖 Match #%d moved to %s",
+
+	"venue.policy_warn_set":  "Overlapping matches at the venue will show a warning",
+	"venue.policy_block_set": "Overlapping matches at the venue are not allowed",
+	"venue.policy_block":     "⛔️ Overlaps not allowed",
+	"venue.policy_warn":      "⚠️ Warn about overlaps",
+
+	"conflict.warn":       "⚠️ The venue is already booked at that time:",
+	"conflict.block":      "⛔️ The venue is already booked at that time and overlaps are not allowed:",
+	"conflict.match":      "Match #%d %s - %s",
+	"conflict.free_slots": "Nearest free time:",
+
+	"day.week": "week",
+
+	"listing.title":       "Match #%d",
+	"listing.start":       "Starts %s",
+	"listing.price":       "%d tg/player",
+	"matches.places_left": "%d place left|%d places left",
+	"matches.distance":    " - 📍 %.1f km",
+
+	"search.ask_location": "Send the name or part of the address of the venue",
+	"search.title":        "🔜 Upcoming matches: %s\n\t%s",
+	"search.filters":      "Filters",
+
+	"filter.max_price":     "💰 up to %dtg",
+	"filter.min_places":    "🏃‍♂️ %d+ place|🏃‍♂️ %d+ places",
+	"filter.radius":        "🧭 within %.0f km",
+	"filter.none":          "No filters",
+	"filter.nearest_first": "⬆️ Soonest first",
+	"filter.latest_first":  "⬇️ Latest first",
+	"filter.today":         "Today",
+	"filter.tomorrow":      "Tomorrow",
+	"filter.week":          "Week",
+	"filter.any_day":       "Any day",
+	"filter.price_option":  "up to %dtg",
+	"filter.any_price":     "Any price",
+	"filter.places_option": "%d+ place|%d+ places",
+	"filter.any_places":    "Any",
+	"filter.any_format":    "Any",
+	"filter.location":      "📍 Place",
+	"filter.near":          "🧭 Nearby",
+	"filter.reset":         "♻️ Reset",
+	"filter.any_gender":    "Anyone",
+	"filter.eligible":      "🙋 For me",
+
+	"roster.reliability":  "🛡 Reliability %d%% (✅ %d, ❌ %d, ⏰ %d)",
+	"roster.came":         "✅ Came",
+	"roster.missed":       "❌ Didn't come",
+	"reliability.option":  "%d%%+",
+	"reliability.any":     "No limit",
+	"reliability.ask":     "🛡 Who can sign up for the match on their own?",
+	"reliability.set":     "🛡 Players with reliability of %d%% or more can sign up",
+	"reliability.set_any": "🛡 Any player can sign up",
+
+	"rating.beginner":     "Beginner",
+	"rating.intermediate": "Intermediate",
+	"rating.advanced":     "Advanced",
+	"rating.pro":          "Pro",
+	"rating.saved":        "Level saved",
+
+	"gender.female": "Women",
+	"gender.male":   "Men",
+
+	"eligibility.level_range":  "%s to %s",
+	"eligibility.level_min":    "%s+",
+	"eligibility.level_max":    "up to %s",
+	"eligibility.age_range":    "aged %d–%d",
+	"eligibility.age_min":      "%d+ y.o.",
+	"eligibility.age_max":      "up to %d y.o.",
+	"eligibility.any_level":    "Any level",
+	"eligibility.any_gender":   "Anyone",
+	"eligibility.any_age":      "Any age",
+	"eligibility.none":         "anyone can play",
+	"eligibility.ask":          "🎯 Who can sign up: %s\n\nRows: lowest level, highest level, gender, lowest age, highest age",
+	"eligibility.invalid":      "The lower bound can't be above the upper one",
+	"eligibility.unmet_level":  "🎯 Your level doesn't fit this match: %s. Ask the organizer to add you",
+	"eligibility.unmet_gender": "🎯 This match is only for: %s",
+	"eligibility.unmet_age":    "🎯 This match is only for: %s. If you haven't set your birth year, do it in /profile",
+
+	"profile.show":               "👤 Your profile\nGender: %s\nBirth year: %s\n\nMatches may be limited by gender and age, the organizer sees neither",
+	"profile.unknown":            "not set",
+	"profile.female":             "Female",
+	"profile.male":               "Male",
+	"profile.birth_year":         "🎂 Set birth year",
+	"profile.ask_birth_year":     "What year were you born? For example, 1995",
+	"profile.invalid_birth_year": "That doesn't look like a birth year, send four digits, for example 1995",
+
+	"chat.open":       "💬 Chat of match %d. Everything you write now goes to the other players and the organizer. Reply to a message to answer it",
+	"chat.leave":      "🚪 Leave chat",
+	"chat.left":       "You left the match chat. Replies to its messages still reach the players",
+	"chat.mute":       "🔕 Mute chat",
+	"chat.unmute":     "🔔 Unmute chat",
+	"chat.muted":      "🔕 You won't get messages from the chat of match %d",
+	"chat.unmuted":    "🔔 You will get messages from the chat of match %d again",
+	"chat.message":    "💬 Match %d · %s:\n%s",
+	"chat.text_only":  "Only text can be sent to the match chat",
+	"chat.not_member": "You are not in match %d, so you can't write to its chat",
+	"chat.too_fast":   "You are writing too fast, wait a minute",
+	"chat.nobody":     "Nobody else gets the chat of this match yet",
+
+	"group.how_to":        "👥 No group is linked to match %[1]d. Add the bot to your group as an admin allowed to invite and ban members, then send there:\n/link_chat %[1]d",
+	"group.linked":        "👥 Match %d is linked to the group «%s». Players get a personal invite link when they sign up and are removed when they leave",
+	"group.unlink":        "🔗 Unlink",
+	"group.unlinked":      "👥 Match %d is no longer linked to «%s», the invite links are revoked",
+	"group.only_in_group": "Send this command in the group you want to link",
+	"group.usage":         "Send /link_chat followed by the match number, for example /link_chat 12",
+	"group.not_organizer": "Only the organizer of match %d can link a group to it",
+	"group.no_rights":     "Make the bot an admin allowed to invite and ban members first",
+	"group.not_admin":     "Only an admin of the group can link it to a match",
+	"group.linked_here":   "👥 This group is linked to match %d. Its players will get invite links",
+	"group.invite":        "👥 Match %d has a group «%s». Join it with your personal link",
+	"group.join":          "Join the group",
+
+	"balance.already": "⚖️ The teams are already balanced",
+	"balance.preview": "⚖️ Suggested rosters:",
+	"balance.apply":   "✅ Apply",
+	"balance.applied": "✅ Rosters updated, players moved: %d",
+	"team.moved":      "🔀 You were moved to team %s in match #%d",
+
+	"signout.late_warning": "⚠️ Signing out for free was possible up to %d h before the match.\n\t" +
+		"If you leave without a replacement, you will owe your share of %dtg and your reliability will drop",
+	"signout.find_replacement": "🔁 Find a replacement",
+	"signout.anyway":           "Sign out anyway",
+	"signout.stay":             "Stay",
+	"signout.done":             "You signed out of the match",
+	"signout.organizer":        "%s signed out of match %d",
+	"signout.liable":           "You owe %dtg for signing out late",
+	"signout.organizer_late":   "%s signed out of match %d after the deadline and owes %dtg",
+
+	"cancel_window.option":          "%d h",
+	"cancel_window.until_start":     "Until kick-off",
+	"cancel_window.ask":             "⏳ How many hours before kick-off can players sign out without a penalty?",
+	"cancel_window.set":             "⏳ Free sign-out — at least %d h before kick-off",
+	"cancel_window.set_until_start": "⏳ Free sign-out — until kick-off",
+
+	"team.rename":        "✏️ Name",
+	"team.badge":         "🎨 Badge",
+	"team.club":          "🏷 Club",
+	"team.ask_name":      "Send the team name. It will be saved as a club that you can pick in future matches",
+	"team.name_too_long": "The name must be at most 32 characters long",
+	"team.ask_badge":     "Choose the team badge",
+	"team.renamed":       "The team is now called %s",
+	"club.none":          "You don't have any clubs yet. Rename a team and its name will be saved as a club",
+	"club.ask":           "Choose a club",
+
+	"report.ask_content":       "Send text, a photo, a video or a document",
+	"report.added":             "📎 Added to the report. Send more or publish it",
+	"report.publish":           "📤 Publish",
+	"report.already_published": "This report is already published",
+	"report.empty":             "The report is empty",
+	"report.published":         "The report was sent to the players",
+	"report.none":              "There are no reports for this match yet",
+	"report.title":             "📰 Report on match #%d by %s",
+
+	"result.request":      "🏁 Match #%d is over. Record the result",
+	"result.record":       "📝 Record the score",
+	"result.ask":          "Send the score separated by spaces in team order: %s\n\tFor example: 3 2",
+	"result.not_numbers":  "The score must be numbers, for example: 3 2",
+	"result.not_finished": "The match isn't over yet, record the score once it is",
+	"result.each_team":    "Give the score of every team, for example: 3 2",
+	"result.notice":       "🏁 Match #%d: %s",
+	"result.ask_scorers":  "Mark the scorers and the best player of the match",
+	"result.done_hint":    "When you are done, open the match card",
+	"result.goal_added":   "Goal added",
+	"result.goal_removed": "Goal removed",
+	"result.mvp_set":      "Best player chosen",
+
+	"stats.none":  "📊 You have no played matches with a result yet",
+	"stats.title": "📊 Your stats",
+	"stats.sport": "\n🏆 %s\nGames: %d | Wins: %d | Draws: %d | Losses: %d\n⚽️ Goals: %d | ⭐️ MVP: %d\n",
+
+	"signup.no_places":  "😥 No places left",
+	"signup.any_team":   "🎲 Any",
+	"signup.ask_team":   "Which team do you want to join?",
+	"signup.unreliable": "🛡 The organizer only lets players with reliability of %d%% or more sign up. Ask them to add you",
+	"signup.conflict":   "This team is full or you are already signed up for the match",
+	"signup.done":       "You signed up for the match",
+	"signup.organizer":  "%s signed up for match %d",
+
+	"switch.no_places":          "The other teams are full",
+	"switch.ask_team":           "Which team do you want to switch to?",
+	"switch.request":            "%s wants to switch to team %s in match #%d",
+	"switch.approve":            "✅ Move",
+	"switch.reject":             "❌ Decline",
+	"switch.requested":          "Request sent to the organizer",
+	"switch.rejected":           "The organizer declined your team switch in match #%d",
+	"switch.rejected_organizer": "Request declined",
+
+	"move.no_places":    "This team is full",
+	"move.not_in_match": "The player is no longer signed up for the match",
+	"move.done":         "%s moved to team %s",
+
+	"transfer.ask": "Send the @username of the player you want to give your place to, or forward their message.\n\t" +
+		"They must have messaged the bot at least once",
+	"transfer.ask_short":      "Send a @username or forward the player's message",
+	"transfer.unknown_player": "This player hasn't messaged the bot yet. Ask them to press /start and try again",
+	"transfer.already_in":     "This player is already signed up for the match",
+	"transfer.not_in_match":   "You are not signed up for this match",
+	"transfer.offer":          "%s offers you their place in the match",
+	"transfer.accept":         "✅ Accept",
+	"transfer.decline":        "❌ Decline",
+	"transfer.offered":        "Offer sent to %s. You stay in the roster until they accept it",
+	"transfer.expired":        "This offer is no longer valid",
+	"transfer.accepted":       "You took a place in match #%d",
+	"transfer.accepted_from":  "%s took your place in match #%d",
+	"transfer.organizer":      "%s gave their place in match %d to %s",
+	"transfer.declined":       "You declined the place",
+	"transfer.declined_from":  "%s declined your place in match #%d",
+
+	"expense.rent":           "🏟 Rent",
+	"expense.balls":          "⚽️ Balls",
+	"expense.water":          "💧 Water",
+	"expense.referee":        "🧑‍⚖️ Referee",
+	"expense.other":          "📦 Other",
+	"expenses.send_split":    "🧮 Send the split",
+	"expenses.clear":         "🗑 Clear",
+	"expenses.ask_amount":    "%s: how much was spent, in tenge?",
+	"expenses.not_number":    "Send the amount as a number, for example: 3000",
+	"expenses.split":         "💰 Cost split for match #%d\nTotal expenses: %dtg\nYour share: %dtg\nYou paid: %dtg\n%s",
+	"expenses.title":         "💰 Expenses for match #%d",
+	"expenses.declared_rent": "%s (declared): %dtg",
+	"expenses.total":         "Total: %dtg",
+	"expenses.players":       "Players: %d",
+	"match.no_players":       "There are no players in the match",
+	"balance.refund":         "✅ You will get back %dtg",
+	"balance.owe":            "❗️ You need to pay %dtg more",
+	"balance.settled":        "👌 All paid",
+
+	"pricing.capacity":            "Rent split over all places",
+	"pricing.signed_up":           "Rent split over signed up",
+	"pricing.attended":            "Rent split over attended",
+	"pricing.fixed":               "Fixed price",
+	"pricing.free":                "Free",
+	"pricing.ask":                 "🏷 How should the player price be calculated?\nNow: %dtg per player",
+	"pricing.ask_fixed":           "How much does each player pay, in tenge?",
+	"pricing.not_number":          "Send the price as a number, for example: 2000",
+	"pricing.updated":             "🏷 Price updated: %s",
+	"discount.title":              "🎟 Player discounts",
+	"discount.none":               "no discount",
+	"discount.free":               "free",
+	"notify.settings":             "🔔 Notifications\nDelivery: %s\nQuiet hours: %s\nTime zone: %s\n\nTap a kind to turn it on or off. Held notifications come together, with a single sound",
+	"notify.instant":              "right away",
+	"notify.digest_mode":          "hourly digest",
+	"notify.switch_digest":        "📬 Deliver as an hourly digest",
+	"notify.switch_instant":       "⚡️ Deliver right away",
+	"notify.quiet_off":            "none",
+	"notify.quiet_range":          "%02d:00–%02d:00",
+	"notify.utc_offset":           "UTC%+d",
+	"notify.digest":               "📬 %d notification while you were away|📬 %d notifications while you were away",
+	"notify.kind.signup":          "Sign-ups",
+	"notify.kind.signout":         "Sign-outs",
+	"notify.kind.confirm":         "Confirmations",
+	"notify.kind.payment":         "Payments",
+	"notify.kind.match":           "Match changes",
+	"notify.kind.invitation":      "Invitations",
+	"notify.kind.report":          "Reports",
+	"notify.kind.chat":            "Match chat",
+	"reach.unreachable":           "⚠️ The bot can't write to these players, let them know some other way:\n%s",
+	"reach.player":                "• %s — %s",
+	"reach.reason.blocked":        "blocked the bot",
+	"reach.reason.chat_not_found": "never started the bot",
+	"reach.reason.deactivated":    "deleted their account",
+}
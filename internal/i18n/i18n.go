@@ -0,0 +1,158 @@
+// Package i18n holds the texts the bot sends in every supported language.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	Kazakh  Lang = "kk"
+	English Lang = "en"
+)
+
+// Default is the language of users whose Telegram language is not supported.
+const Default = Russian
+
+// Langs are the supported languages in the order they are offered in.
+var Langs = []Lang{Russian, Kazakh, English}
+
+type catalog struct {
+	messages map[string]string
+	// plural returns the index of the plural form used with n.
+	plural func(n int64) int
+	// forms is how many plural forms the messages used with N hold.
+	forms int
+}
+
+var catalogs = map[Lang]*catalog{
+	Russian: {messages: ru, plural: russianPlural, forms: 3},
+	Kazakh:  {messages: kk, plural: noPlural, forms: 1},
+	English: {messages: en, plural: onePlural, forms: 2},
+}
+
+// Parse returns the supported language for a Telegram language code such
+// as "kk" or "en-US", or Default.
+func Parse(code string) Lang {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	if _, ok := catalogs[Lang(code)]; ok {
+		return Lang(code)
+	}
+	return Default
+}
+
+// Name is the name of the language in the language itself.
+func (l Lang) Name() string {
+	return T(l, "language.name")
+}
+
+// T returns the message in the language formatted with the args. Messages
+// missing in the language fall back to Default, and unknown keys are
+// returned as is so that a gap in the catalog shows up in the chat.
+func T(lang Lang, key string, args ...any) string {
+	msg, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+	return format(msg, args)
+}
+
+// N is T for messages that depend on a count. Such messages hold the
+// plural forms of the language separated by "|", and n picks one of them.
+func N(lang Lang, key string, n int64, args ...any) string {
+	msg, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+	c := catalogs[Default]
+	if l, ok := catalogs[lang]; ok {
+		if _, ok := l.messages[key]; ok {
+			c = l
+		}
+	}
+	forms := strings.Split(msg, "|")
+	i := c.plural(n)
+	if i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return format(forms[i], args)
+}
+
+// Missing lists the messages some of the languages lack, as "lang: key".
+func Missing() []string {
+	var missing []string
+	for _, lang := range Langs {
+		for _, other := range Langs {
+			for key := range catalogs[other].messages {
+				if _, ok := catalogs[lang].messages[key]; !ok {
+					missing = append(missing, fmt.Sprintf("%s: %s", lang, key))
+				}
+			}
+		}
+	}
+	sort.Strings(missing)
+	return dedup(missing)
+}
+
+func lookup(lang Lang, key string) (string, bool) {
+	if c, ok := catalogs[lang]; ok {
+		if msg, ok := c.messages[key]; ok {
+			return msg, true
+		}
+	}
+	msg, ok := catalogs[Default].messages[key]
+	return msg, ok
+}
+
+func format(msg string, args []any) string {
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+func dedup(sorted []string) []string {
+	out := sorted[:0]
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// russianPlural picks between "1 место", "2 места" and "5 мест".
+func russianPlural(n int64) int {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// noPlural is the rule of languages that keep the noun singular after a
+// number.
+func noPlural(int64) int {
+	return 0
+}
+
+// onePlural is the rule of languages that only tell one from many.
+func onePlural(n int64) int {
+	if n == 1 || n == -1 {
+		return 0
+	}
+	return 1
+}
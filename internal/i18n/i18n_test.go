@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMissing(t *testing.T) {
+	for _, missing := range Missing() {
+		t.Errorf("missing translation %s", missing)
+	}
+}
+
+// pluralCall matches the key of a message used with N, either in Go code
+// or with tn in a template.
+var pluralCall = regexp.MustCompile(`(?:\bN\(|\btn )[^"\n]*"([a-z0-9_.]+)"`)
+
+// pluralKeys finds the messages used with N in the code of the bot.
+func pluralKeys(t *testing.T) map[string]bool {
+	t.Helper()
+	keys := map[string]bool{}
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(path, "_test.go") || !(strings.HasSuffix(path, ".go") || strings.HasSuffix(path, ".tmpl")) {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range pluralCall.FindAllStringSubmatch(string(src), -1) {
+			keys[m[1]] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) == 0 {
+		t.Fatal("no messages used with N found")
+	}
+	return keys
+}
+
+func TestPluralForms(t *testing.T) {
+	for key := range pluralKeys(t) {
+		for _, lang := range Langs {
+			c := catalogs[lang]
+			msg, ok := c.messages[key]
+			if !ok {
+				t.Errorf("%s: %s is missing", lang, key)
+				continue
+			}
+			if forms := len(strings.Split(msg, "|")); forms != c.forms {
+				t.Errorf("%s: %s has %d plural forms, want %d", lang, key, forms, c.forms)
+			}
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		n    int64
+		want string
+	}{
+		{Russian, 1, "1 команда"},
+		{Russian, 3, "3 команды"},
+		{Russian, 5, "5 команд"},
+		{Russian, 11, "11 команд"},
+		{Russian, 21, "21 команда"},
+		{Russian, 112, "112 команд"},
+		{English, 1, "1 team"},
+		{English, 2, "2 teams"},
+		{English, 0, "0 teams"},
+		{Kazakh, 1, "1 команда"},
+		{Kazakh, 5, "5 команда"},
+	}
+	for _, tt := range tests {
+		if got := N(tt.lang, "match.teams", tt.n, tt.n); got != tt.want {
+			t.Errorf("N(%s, match.teams, %d) = %q, want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package i18n
+
+var kk = map[string]string{
+	"language.name": "Қазақша",
+	"language.ask":  "🌐 Тілді таңдаңыз",
+	"language.set":  "🌐 Енді мен қазақша сөйлеймін",
+
+	"sport.ask":     "Спорт түрін таңдаңыз",
+	"sport.list":    "Спорт түрлерінің тізімі:",
+	"sport.usage":   "Спорт түрін қосу немесе өзгерту:\n/sport код эмодзи команда_өлшемдері минут команда Название|Атауы|Name\nМысалы: /sport padel 🎾 2 90 2 Падел|Падел|Padel",
+	"sport.saved":   "✅ Сақталды: %s",
+	"sport.invalid": "⚠️ %s",
+
+	"day.today":    "бүгін",
+	"day.tomorrow": "ертең",
+
+	"duration.hours": "%.1f сағат",
+
+	"card.title":     "Ойын #%d",
+	"card.sport":     "Спорт",
+	"card.organizer": "Ұйымдастырушы",
+	"card.date":      "Матч күні",
+	"card.start":     "Басталуы",
+	"card.format":    "Формат",
+
+	"match.teams":         "%d команда",
+	"match.score":         "🏁 Есеп: %s",
+	"match.places_left":   "🏃‍♂️%d орын қалды",
+	"match.you_cancelled": "Сіз матчты болдырмадыңыз",
+	"match.cancelled":     "#%d матч болдырылмады",
+	"match.fee_refunded":  "#%d матч үшін жарнаңыз қайтарылды",
+
+	"matches.mine":      "🔜 Сіздің жақын матчтарыңыз",
+	"matches.organized": "🔜 Сіз ұйымдастырған матчтар",
+	"matches.none":      "😥 Өкінішке орай, матчтар жоқ",
+
+	"price.free":       "Тегін",
+	"price.per_player": "Әр адамнан %dтг",
+	"price.for_you":    "🎟 Сіз үшін: %dтг",
+
+	"button.match_more":      "Матч туралы толығырақ",
+	"button.add_members":     "Қатысушыларды қосу",
+	"button.cancel_match":    "Матчты болдырмау",
+	"button.send_report":     "Есеп жіберу",
+	"button.reschedule":      "🕖 Ауыстыру",
+	"button.expenses":        "💰 Шығындар",
+	"button.ratings":         "⭐ Ойыншылар деңгейі",
+	"button.balance":         "⚖️ Теңестіру",
+	"button.move_members":    "🔀 Ауыстыру",
+	"button.teams":           "🎨 Командалар",
+	"button.roster":          "📋 Құрам",
+	"button.min_reliability": "🛡 Сенімділік шегі",
+	"button.cancel_window":   "⏳ Қатысудан бас тарту",
+	"button.eligibility":     "🎯 Кім ойнай алады",
+	"button.chat":            "💬 Чат",
+	"button.group":           "👥 Топ",
+	"button.pricing":         "🏷 Баға",
+	"button.discounts":       "🎟 Жеңілдіктер",
+	"button.result":          "📝 Нәтиже",
+	"button.reports":         "🖼 Есептер",
+	"button.signup":          "Матчқа жазылу",
+	"button.signout":         "Қатысудан бас тарту",
+	"button.transfer":        "🔁 Орынды беру",
+	"button.switch_team":     "🔀 Команданы ауыстыру",
+	"button.confirm":         "Қатысуды растау",
+	"button.pay":             "Жарнаны төлеу",
+
+	"payment.done":      "Сіз жарнаны төледіңіз",
+	"payment.organizer": "%s %d матч үшін жарнаны төледі",
+	"confirm.done":      "Сіз матчқа қатысуды растадыңыз",
+	"confirm.organizer": "%s %d матчқа қатысуды растады",
+
+	"create.ask_time":       "Матч қай уақытта болады?",
+	"create.ask_duration":   "Матч ұзақтығы қанша?",
+	"create.ask_team_size":  "Командада неше адам?",
+	"create.ask_team_count": "Неше команда?",
+	"create.public":         "Ашық",
+	"create.private":        "Жабық",
+	"create.organize":       "Ұйымдастыру",
+	"create.cancel":         "Болдырмау",
+
+	"members.choose_team": "Командаға қосу:",
+	"members.ask": "Қосқыңыз келетін адамдардың юзернеймдерін бос орын арқылы " +
+		"және басында \"@\" белгісімен жіберіңіз немесе олардың хабарламасын не контактісін жіберіңіз",
+	"invitation.received": "Сізді матчқа шақырады",
+	"invitation.claimed":  "%s ботқа қосылып, #%d матчқа қосылды",
+	"invite.nobody":       "Хабарламадан бірде-бір қатысушы табылмады",
+	"invite.added":        "✅ Қосылды: %s",
+	"invite.pending":      "⏳ Ботты іске қосуын күтуде: %s",
+	"invite.invalid":      "❌ Шақыру мүмкін болмады: %s",
+	"invite.already_in":   "👌 Құрамда бар: %s",
+	"invite.filled":       "🚫 Осы уақытта команда толып қалды, ешкім қосылмады. Бос орындарды көру үшін матчты ашыңыз",
+	"invite.full":         "🚫 Командада орын жоқ: %s",
+
+	"report.ask": "Матч туралы есеп жіберіңіз: мәтін, фото, видео немесе құжаттар",
+
+	"money": "%dтг",
+
+	"venue.ask":             "Матч қай жерде болады?\n\tАлаңды таңдаңыз, жаңасының атауын жіберіңіз немесе геолокациямен бөлісіңіз",
+	"venue.ask_name":        "Алаң атауын немесе геолокацияны жіберіңіз",
+	"venues.none":           "Сіз әлі бірде-бір алаң қоспадыңыз",
+	"venue.rent":            "💰 Жалға алу: %dтг",
+	"venue.set_location":    "📍 Геолокацияны көрсету",
+	"venue.change_location": "📍 Геолокацияны өзгерту",
+	"venue.photo":           "🖼 Фото",
+	"venue.ask_location":    "Алаңның геолокациясын жіберіңіз",
+	"venue.ask_photo":       "Алаңның фотосын жіберіңіз",
+	"venue.location_saved":  "Алаңның геолокациясы сақталды",
+	"venue.photo_saved":     "Алаңның фотосы сақталды",
+	"location.ask_attach":   "Геолокацияны 📎 арқылы жіберіңіз",
+	"location.share":        "📍 Геолокацияны жіберу",
+
+	"create.ask_day":     "Матч қай күні болады?",
+	"create.ask_rent":    "Жалға алу қанша тұрады?",
+	"create.ask_private": "Матч жабық па, ашық па?",
+
+	"search.ask_near": "Жақын матчтарды табу үшін геолокациямен бөлісіңіз",
+	"search.near":     "📍 Жаныңыздағы матчтарды іздеп жатырмын",
+
+	"create.ask_confirm":     "Матчты ұйымдастырасыз ба?",
+	"create.organize_anyway": "Бәрібір ұйымдастыру",
+
+	"reschedule.no_slots": "😥 Алаңда жақын арада бос уақыт табылмады",
+	"reschedule.ask":      "#%d матчты қай уақытқа ауыстырамыз?",
+	"reschedule.busy":     "⛔️ Бұл уақытта алаң бос емес",
+	"reschedule.done":     "🕖 #%d матч %s уақытына ауыстырылды",
+
+	"venue.policy_warn_set":  "Алаңдағы қиылысатын матчтар ескертумен болады",
+	"venue.policy_block_set": "Алаңдағы қиылысатын матчтарға тыйым салынды",
+	"venue.policy_block":     "⛔️ Қиылысуға тыйым салынған",
+	"venue.policy_warn":      "⚠️ Қиылысу ескертумен",
+
+	"conflict.warn":       "⚠️ Бұл уақытта алаң бос емес:",
+	"conflict.block":      "⛔️ Бұл уақытта алаң бос емес, қиылысуға тыйым салынған:",
+	"conflict.match":      "#%d матч %s - %s",
+	"conflict.free_slots": "Ең жақын бос уақыт:",
+
+	"day.week": "апта",
+
+	"listing.title":       "#%d матч",
+	"listing.start":       "Басталуы %s",
+	"listing.price":       "%d тг/адам",
+	"matches.places_left": "%d орын қалды",
+	"matches.distance":    " - 📍 %.1f км",
+
+	"search.ask_location": "Алаңның атауын немесе мекенжайының бір бөлігін жіберіңіз",
+	"search.title":        "🔜 Жақын матчтар: %s\n\t%s",
+	"search.filters":      "Сүзгілер",
+
+	"filter.max_price":     "💰 %dтг дейін",
+	"filter.min_places":    "🏃‍♂️ %d орыннан бастап",
+	"filter.radius":        "🧭 %.0f км радиуста",
+	"filter.none":          "Сүзгісіз",
+	"filter.nearest_first": "⬆️ Алдымен жақындары",
+	"filter.latest_first":  "⬇️ Алдымен алыстары",
+	"filter.today":         "Бүгін",
+	"filter.tomorrow":      "Ертең",
+	"filter.week":          "Апта",
+	"filter.any_day":       "Кез келген күн",
+	"filter.price_option":  "%dтг дейін",
+	"filter.any_price":     "Кез келген баға",
+	"filter.places_option": "%d орыннан",
+	"filter.any_places":    "Кез келген",
+	"filter.any_format":    "Кез келген",
+	"filter.location":      "📍 Орын",
+	"filter.near":          "🧭 Жақын маңда",
+	"filter.reset":         "♻️ Тазалау",
+	"filter.any_gender":    "Барлығы",
+	"filter.eligible":      "🙋 Маған сәйкес",
+
+	"roster.reliability":  "🛡 Сенімділік %d%% (✅ %d, ❌ %d, ⏰ %d)",
+	"roster.came":         "✅ Келді",
+	"roster.missed":       "❌ Келмеді",
+	"reliability.option":  "%d%% бастап",
+	"reliability.any":     "Шектеусіз",
+	"reliability.ask":     "🛡 Матчқа кім өзі жазыла алады?",
+	"reliability.set":     "🛡 Сенімділігі %d%% бастап ойыншылар жазыла алады",
+	"reliability.set_any": "🛡 Кез келген ойыншы жазыла алады",
+
+	"rating.beginner":     "Бастаушы",
+	"rating.intermediate": "Орташа",
+	"rating.advanced":     "Мықты",
+	"rating.pro":          "Кәсіпқой",
+	"rating.saved":        "Деңгей сақталды",
+
+	"gender.female": "Әйелдер",
+	"gender.male":   "Ерлер",
+
+	"eligibility.level_range":  "%s – %s",
+	"eligibility.level_min":    "%s+",
+	"eligibility.level_max":    "%s дейін",
+	"eligibility.age_range":    "%d–%d жас",
+	"eligibility.age_min":      "%d+ жас",
+	"eligibility.age_max":      "%d жасқа дейін",
+	"eligibility.any_level":    "Кез келген деңгей",
+	"eligibility.any_gender":   "Барлығы",
+	"eligibility.any_age":      "Кез келген жас",
+	"eligibility.none":         "барлығы ойнай алады",
+	"eligibility.ask":          "🎯 Кім жазыла алады: %s\n\nҚатарлар: ең төменгі деңгей, ең жоғарғы деңгей, жынысы, ең төменгі жас, ең жоғарғы жас",
+	"eligibility.invalid":      "Төменгі шек жоғарғы шектен үлкен бола алмайды",
+	"eligibility.unmet_level":  "🎯 Деңгейіңіз матчқа сәйкес келмейді: %s. Ұйымдастырушыдан сізді қосуын сұраңыз",
+	"eligibility.unmet_gender": "🎯 Матч тек мыналарға арналған: %s",
+	"eligibility.unmet_age":    "🎯 Матч тек мыналарға арналған: %s. Туған жылыңызды көрсетпеген болсаңыз, /profile арқылы көрсетіңіз",
+
+	"profile.show":               "👤 Сіздің профиліңіз\nЖынысы: %s\nТуған жылы: %s\n\nМатчтар жынысы мен жасы бойынша шектелуі мүмкін, ұйымдастырушы оларды көрмейді",
+	"profile.unknown":            "көрсетілмеген",
+	"profile.female":             "Әйел",
+	"profile.male":               "Ер",
+	"profile.birth_year":         "🎂 Туған жылды көрсету",
+	"profile.ask_birth_year":     "Қай жылы туылдыңыз? Мысалы, 1995",
+	"profile.invalid_birth_year": "Бұл туған жылға ұқсамайды, төрт цифр жіберіңіз, мысалы 1995",
+
+	"chat.open":       "💬 %d матчының чаты. Енді жазғаныңыздың бәрін басқа ойыншылар мен ұйымдастырушы алады. Хабарламаға жауап беру үшін «Жауап беру» түймесін қолданыңыз",
+	"chat.leave":      "🚪 Чаттан шығу",
+	"chat.left":       "Сіз матч чатынан шықтыңыз. Оның хабарламаларына жауаптар ойыншыларға бәрібір жетеді",
+	"chat.mute":       "🔕 Чатты өшіру",
+	"chat.unmute":     "🔔 Чатты қосу",
+	"chat.muted":      "🔕 Сіз %d матчының чатынан хабарлама алмайсыз",
+	"chat.unmuted":    "🔔 Сіз %d матчының чатынан қайтадан хабарлама аласыз",
+	"chat.message":    "💬 Матч %d · %s:\n%s",
+	"chat.text_only":  "Матч чатына тек мәтін жіберуге болады",
+	"chat.not_member": "Сіз %d матчына қатыспайсыз, сондықтан оның чатына жаза алмайсыз",
+	"chat.too_fast":   "Сіз тым жиі жазып жатырсыз, бір минут күтіңіз",
+	"chat.nobody":     "Бұл матчтың чатында әзірге басқа ешкім жоқ",
+
+	"group.how_to":        "👥 %[1]d матчына топ байланыстырылмаған. Ботты тобыңызға қатысушыларды шақыру және бұғаттау құқығы бар әкімші ретінде қосып, онда жіберіңіз:\n/link_chat %[1]d",
+	"group.linked":        "👥 %d матчы «%s» тобына байланыстырылған. Ойыншылар жазылғанда жеке сілтеме алады, ал кеткенде топтан шығарылады",
+	"group.unlink":        "🔗 Ажырату",
+	"group.unlinked":      "👥 %d матчы енді «%s» тобына байланысты емес, шақыру сілтемелері кері қайтарылды",
+	"group.only_in_group": "Бұл команданы байланыстырғыңыз келетін топта жіберіңіз",
+	"group.usage":         "/link_chat және матч нөмірін жіберіңіз, мысалы /link_chat 12",
+	"group.not_organizer": "%d матчына топты тек оның ұйымдастырушысы байланыстыра алады",
+	"group.no_rights":     "Алдымен ботты қатысушыларды шақыру және бұғаттау құқығы бар әкімші етіңіз",
+	"group.not_admin":     "Топты матчқа тек оның әкімшісі байланыстыра алады",
+	"group.linked_here":   "👥 Топ %d матчына байланыстырылды. Матч ойыншылары шақыру сілтемелерін алады",
+	"group.invite":        "👥 %d матчының «%s» тобы бар. Жеке сілтеме арқылы қосылыңыз",
+	"group.join":          "Топқа қосылу",
+
+	"balance.already": "⚖️ Командалар қазірдің өзінде теңестірілген",
+	"balance.preview": "⚖️ Ұсынылатын құрамдар:",
+	"balance.apply":   "✅ Қолдану",
+	"balance.applied": "✅ Құрамдар жаңартылды, ауыстырылған ойыншылар: %d",
+	"team.moved":      "🔀 Сізді %s командасына #%d матчта ауыстырды",
+
+	"signout.late_warning": "⚠️ Қатысудан тегін бас тарту матч басталуына %d сағ. қалғанға дейін мүмкін еді.\n\t" +
+		"Егер ауыстырусыз кетсеңіз, өз үлесіңізді %dтг төлеуге тиіссіз, бұл сенімділігіңізді төмендетеді",
+	"signout.find_replacement": "🔁 Ауыстырушы табу",
+	"signout.anyway":           "Бәрібір бас тарту",
+	"signout.stay":             "Қалу",
+	"signout.done":             "Сіз матчқа қатысудан бас тарттыңыз",
+	"signout.organizer":        "%s %d матчқа қатысудан бас тартты",
+	"signout.liable":           "Кеш бас тартқаныңыз үшін %dтг төлеуіңіз керек",
+	"signout.organizer_late":   "%s %d матчқа қатысудан мерзімнен кейін бас тартты, қарызы %dтг",
+
+	"cancel_window.option":          "%d сағ.",
+	"cancel_window.until_start":     "Басталғанға дейін",
+	"cancel_window.ask":             "⏳ Ойыншылар басталуына неше сағат қалғанға дейін айыппұлсыз бас тарта алады?",
+	"cancel_window.set":             "⏳ Тегін бас тарту — басталуына кемінде %d сағ. қалғанда",
+	"cancel_window.set_until_start": "⏳ Тегін бас тарту — матч басталғанға дейін",
+
+	"team.rename":        "✏️ Атауы",
+	"team.badge":         "🎨 Белгі",
+	"team.club":          "🏷 Клуб",
+	"team.ask_name":      "Команда атауын жіберіңіз. Ол клуб ретінде сақталады және оны келесі матчтарда таңдауға болады",
+	"team.name_too_long": "Атауы 32 таңбадан аспауы керек",
+	"team.ask_badge":     "Команда белгісін таңдаңыз",
+	"team.renamed":       "Команда енді %s деп аталады",
+	"club.none":          "Сізде әлі клуб жоқ. Команданың атын өзгертіңіз, атауы клуб ретінде сақталады",
+	"club.ask":           "Клубты таңдаңыз",
+
+	"report.ask_content":       "Мәтін, фото, видео немесе құжат жіберіңіз",
+	"report.added":             "📎 Есепке қосылды. Тағы жіберіңіз немесе жариялаңыз",
+	"report.publish":           "📤 Жариялау",
+	"report.already_published": "Бұл есеп жарияланып қойған",
+	"report.empty":             "Есеп бос",
+	"report.published":         "Есеп қатысушыларға жіберілді",
+	"report.none":              "Бұл матч бойынша әлі есеп жоқ",
+	"report.title":             "📰 #%d матч бойынша есеп, авторы %s",
+
+	"result.request":      "🏁 #%d матч аяқталды. Нәтижесін жазыңыз",
+	"result.record":       "📝 Есепті жазу",
+	"result.ask":          "Есепті командалар ретімен бос орын арқылы жіберіңіз: %s\n\tМысалы: 3 2",
+	"result.not_numbers":  "Есеп сандардан тұруы керек, мысалы: 3 2",
+	"result.not_finished": "Матч әлі аяқталған жоқ, есепті ол біткен соң жазыңыз",
+	"result.each_team":    "Әр команданың есебін көрсетіңіз, мысалы: 3 2",
+	"result.notice":       "🏁 #%d матч: %s",
+	"result.ask_scorers":  "Гол авторлары мен матчтың үздік ойыншысын белгілеңіз",
+	"result.done_hint":    "Аяқтаған соң матч карточкасын ашыңыз",
+	"result.goal_added":   "Гол есептелді",
+	"result.goal_removed": "Гол алынып тасталды",
+	"result.mvp_set":      "Үздік ойыншы таңдалды",
+
+	"stats.none":  "📊 Сізде әлі нәтижесі бар ойналған матчтар жоқ",
+	"stats.title": "📊 Сіздің статистикаңыз",
+	"stats.sport": "\n🏆 %s\nОйын: %d | Жеңіс: %d | Тең: %d | Жеңіліс: %d\n⚽️ Гол: %d | ⭐️ MVP: %d\n",
+
+	"signup.no_places":  "😥 Бос орын қалмады",
+	"signup.any_team":   "🎲 Кез келген",
+	"signup.ask_team":   "Қай командаға жазыласыз?",
+	"signup.unreliable": "🛡 Ұйымдастырушы тек сенімділігі %d%% бастап ойыншыларға жазылуды ашты. Сізді қосуын сұраңыз",
+	"signup.conflict":   "Бұл командада орын жоқ немесе сіз матчқа жазылып қойғансыз",
+	"signup.done":       "Сіз матчқа жазылдыңыз",
+	"signup.organizer":  "%s %d матчқа жазылды",
+
+	"switch.no_places":          "Басқа командаларда бос орын жоқ",
+	"switch.ask_team":           "Қай командаға ауысқыңыз келеді?",
+	"switch.request":            "%s %s командасына ауысқысы келеді, #%d матч",
+	"switch.approve":            "✅ Ауыстыру",
+	"switch.reject":             "❌ Қабылдамау",
+	"switch.requested":          "Сұраныс ұйымдастырушыға жіберілді",
+	"switch.rejected":           "Ұйымдастырушы #%d матчта басқа командаға ауысуыңызды қабылдамады",
+	"switch.rejected_organizer": "Сұраныс қабылданбады",
+
+	"move.no_places":    "Бұл командада бос орын жоқ",
+	"move.not_in_match": "Ойыншы енді матчқа жазылмаған",
+	"move.done":         "%s %s командасына ауыстырылды",
+
+	"transfer.ask": "Орныңызды бергіңіз келетін ойыншының @username-ін жіберіңіз немесе оның хабарламасын жіберіңіз.\n\t" +
+		"Ол ботқа кемінде бір рет жазған болуы керек",
+	"transfer.ask_short":      "@username жіберіңіз немесе ойыншының хабарламасын жіберіңіз",
+	"transfer.unknown_player": "Бұл ойыншы ботқа әлі жазбаған. Одан /start басуды сұраңыз да, қайталап көріңіз",
+	"transfer.already_in":     "Бұл ойыншы матчқа жазылып қойған",
+	"transfer.not_in_match":   "Сіз бұл матчқа жазылмағансыз",
+	"transfer.offer":          "%s сізге матчтағы өз орнын ұсынады",
+	"transfer.accept":         "✅ Қабылдау",
+	"transfer.decline":        "❌ Бас тарту",
+	"transfer.offered":        "Ұсыныс %s ойыншысына жіберілді. Ол қабылдағанша сіз құрамда қаласыз",
+	"transfer.expired":        "Бұл ұсыныс енді жарамсыз",
+	"transfer.accepted":       "Сіз #%d матчта орын алдыңыз",
+	"transfer.accepted_from":  "%s #%d матчтағы орныңызды қабылдады",
+	"transfer.organizer":      "%s %d матчтағы орнын %s ойыншысына берді",
+	"transfer.declined":       "Сіз орыннан бас тарттыңыз",
+	"transfer.declined_from":  "%s #%d матчтағы орныңыздан бас тартты",
+
+	"expense.rent":           "🏟 Жалға алу",
+	"expense.balls":          "⚽️ Доптар",
+	"expense.water":          "💧 Су",
+	"expense.referee":        "🧑‍⚖️ Төреші",
+	"expense.other":          "📦 Басқа",
+	"expenses.send_split":    "🧮 Есеп-қисапты тарату",
+	"expenses.clear":         "🗑 Тазалау",
+	"expenses.ask_amount":    "%s: қанша жұмсалды, теңгемен?",
+	"expenses.not_number":    "Соманы санмен жіберіңіз, мысалы: 3000",
+	"expenses.split":         "💰 #%d матч бойынша есеп-қисап\nЖалпы шығын: %dтг\nСіздің үлесіңіз: %dтг\nСіз төледіңіз: %dтг\n%s",
+	"expenses.title":         "💰 #%d матч бойынша шығындар",
+	"expenses.declared_rent": "%s (мәлімделген): %dтг",
+	"expenses.total":         "Барлығы: %dтг",
+	"expenses.players":       "Ойыншылар: %d",
+	"match.no_players":       "Матчта ойыншылар жоқ",
+	"balance.refund":         "✅ Сізге %dтг қайтарылады",
+	"balance.owe":            "❗️ %dтг қосымша төлеу керек",
+	"balance.settled":        "👌 Барлығы төленген",
+
+	"pricing.capacity":            "Жалға алу барлық орынға",
+	"pricing.signed_up":           "Жалға алу жазылғандарға",
+	"pricing.attended":            "Жалға алу келгендерге",
+	"pricing.fixed":               "Тіркелген баға",
+	"pricing.free":                "Тегін",
+	"pricing.ask":                 "🏷 Ойыншылар үшін баға қалай есептеледі?\nҚазір: әр адамнан %dтг",
+	"pricing.ask_fixed":           "Әр ойыншы қанша төлейді, теңгемен?",
+	"pricing.not_number":          "Бағаны санмен жіберіңіз, мысалы: 2000",
+	"pricing.updated":             "🏷 Баға жаңартылды: %s",
+	"discount.title":              "🎟 Ойыншыларға жеңілдіктер",
+	"discount.none":               "жеңілдіксіз",
+	"discount.free":               "тегін",
+	"notify.settings":             "🔔 Хабарламалар\nЖеткізу: %s\nТыныш сағаттар: %s\nУақыт белдеуі: %s\n\nХабарлама түрін қосу не өшіру үшін басыңыз. Кейінге қалдырылған хабарламалар бір дыбыспен бірге келеді",
+	"notify.instant":              "бірден",
+	"notify.digest_mode":          "сағат сайынғы жинақ",
+	"notify.switch_digest":        "📬 Сағат сайын жинақпен жіберу",
+	"notify.switch_instant":       "⚡️ Бірден жіберу",
+	"notify.quiet_off":            "жоқ",
+	"notify.quiet_range":          "%02d:00–%02d:00",
+	"notify.utc_offset":           "UTC%+d",
+	"notify.digest":               "📬 Сіз жоқта %d хабарлама келді",
+	"notify.kind.signup":          "Жазылулар",
+	"notify.kind.signout":         "Бас тартулар",
+	"notify.kind.confirm":         "Растаулар",
+	"notify.kind.payment":         "Төлемдер",
+	"notify.kind.match":           "Матч өзгерістері",
+	"notify.kind.invitation":      "Шақырулар",
+	"notify.kind.report":          "Есептер",
+	"notify.kind.chat":            "Матч чаты",
+	"reach.unreachable":           "⚠️ Бот бұл ойыншыларға жаза алмайды, оларға басқа жолмен хабарлаңыз:\n%s",
+	"reach.player":                "• %s — %s",
+	"reach.reason.blocked":        "ботты бұғаттаған",
+	"reach.reason.chat_not_found": "ботты іске қоспаған",
+	"reach.reason.deactivated":    "аккаунтын жойған",
+}
@@ -0,0 +1,388 @@
+package i18n
+
+var ru = map[string]string{
+	"language.name": "Русский",
+	"language.ask":  "🌐 Выберите язык",
+	"language.set":  "🌐 Теперь я говорю по-русски",
+
+	"sport.ask":     "Выберите вид спорта",
+	"sport.list":    "Список видов спорта:",
+	"sport.usage":   "Добавить или изменить вид спорта:\n/sport код эмодзи размеры_команд минуты команд Название|Атауы|Name\nНапример: /sport padel 🎾 2 90 2 Падел|Падел|Padel",
+	"sport.saved":   "✅ Сохранено: %s",
+	"sport.invalid": "⚠️ %s",
+
+	"day.today":    "сегодня",
+	"day.tomorrow": "завтра",
+
+	"duration.hours": "%.1f часа",
+
+	"card.title":     "Игра #%d",
+	"card.sport":     "Спорт",
+	"card.organizer": "Организатор",
+	"card.date":      "Дата матча",
+	"card.start":     "Начало матча",
+	"card.format":    "Формат",
+
+	"match.teams":         "%d команда|%d команды|%d команд",
+	"match.score":         "🏁 Счёт: %s",
+	"match.places_left":   "🏃‍♂️Осталось %d место|🏃‍♂️Осталось %d места|🏃‍♂️Осталось %d мест",
+	"match.you_cancelled": "Вы отменили матч",
+	"match.cancelled":     "Матч #%d отменен",
+	"match.fee_refunded":  "Ваш взнос за матч #%d был возвращен",
+
+	"matches.mine":      "🔜 Ближайшие ваши матчи",
+	"matches.organized": "🔜 Матчи организованные вами",
+	"matches.none":      "😥 К сожалению, матчей нет",
+
+	"price.free":       "Бесплатно",
+	"price.per_player": "С человека по %dтг",
+	"price.for_you":    "🎟 Для вас: %dтг",
+
+	"button.match_more":      "Подробнее о матче",
+	"button.add_members":     "Добавить участников",
+	"button.cancel_match":    "Отменить матч",
+	"button.send_report":     "Отправить отчет",
+	"button.reschedule":      "🕖 Перенести",
+	"button.expenses":        "💰 Расходы",
+	"button.ratings":         "⭐ Уровни игроков",
+	"button.balance":         "⚖️ Сбалансировать",
+	"button.move_members":    "🔀 Переставить",
+	"button.teams":           "🎨 Команды",
+	"button.roster":          "📋 Состав",
+	"button.min_reliability": "🛡 Порог надёжности",
+	"button.cancel_window":   "⏳ Отмена участия",
+	"button.eligibility":     "🎯 Кто может играть",
+	"button.chat":            "💬 Чат",
+	"button.group":           "👥 Группа",
+	"button.pricing":         "🏷 Цена",
+	"button.discounts":       "🎟 Скидки",
+	"button.result":          "📝 Результат",
+	"button.reports":         "🖼 Отчеты",
+	"button.signup":          "Записаться на матч",
+	"button.signout":         "Отменить участие",
+	"button.transfer":        "🔁 Передать место",
+	"button.switch_team":     "🔀 Сменить команду",
+	"button.confirm":         "Подтвердить участие",
+	"button.pay":             "Оплатить взнос",
+
+	"payment.done":      "Вы оплатили взнос",
+	"payment.organizer": "%s оплатил взнос в матче %d",
+	"confirm.done":      "Вы подтвердили участие в матче",
+	"confirm.organizer": "%s подтвердил участие в матче %d",
+
+	"create.ask_time":       "В какое время будет матч?",
+	"create.ask_duration":   "Длительность матча?",
+	"create.ask_team_size":  "Сколько человек в команде?",
+	"create.ask_team_count": "Сколько команд?",
+	"create.public":         "Открытый",
+	"create.private":        "Закрытый",
+	"create.organize":       "Организовать",
+	"create.cancel":         "Отменить",
+
+	"members.choose_team": "Добавить в команду:",
+	"members.ask": "Отправьте юзернеймы тех, кого хотите добавить, " +
+		"через пробел и с \"@\" в начале, или перешлите их сообщение либо контакт",
+	"invitation.received": "Вас приглашают на матч",
+	"invitation.claimed":  "%s присоединился к боту и добавлен в матч #%d",
+	"invite.nobody":       "Не удалось найти ни одного участника в сообщении",
+	"invite.added":        "✅ Добавлены: %s",
+	"invite.pending":      "⏳ Ожидают запуска бота: %s",
+	"invite.invalid":      "❌ Не удалось пригласить: %s",
+	"invite.already_in":   "👌 Уже в составе: %s",
+	"invite.filled":       "🚫 Команда тем временем заполнилась, никто не добавлен. Откройте матч, чтобы увидеть свободные места",
+	"invite.full":         "🚫 Нет мест в команде: %s",
+
+	"report.ask": "Отправьте отчет о матче: текст, фото, видео или документы",
+
+	"money": "%dтг",
+
+	"venue.ask":             "Где будет матч?\n\tВыберите площадку, отправьте название новой или поделитесь геолокацией",
+	"venue.ask_name":        "Отправьте название площадки или геолокацию",
+	"venues.none":           "Вы еще не добавили ни одной площадки",
+	"venue.rent":            "💰 Аренда: %dтг",
+	"venue.set_location":    "📍 Указать геолокацию",
+	"venue.change_location": "📍 Изменить геолокацию",
+	"venue.photo":           "🖼 Фото",
+	"venue.ask_location":    "Отправьте геолокацию площадки",
+	"venue.ask_photo":       "Отправьте фото площадки",
+	"venue.location_saved":  "Геолокация площадки сохранена",
+	"venue.photo_saved":     "Фото площадки сохранено",
+	"location.ask_attach":   "Отправьте геолокацию через 📎",
+	"location.share":        "📍 Отправить геолокацию",
+
+	"create.ask_day":     "В какой день будет матч?",
+	"create.ask_rent":    "Сколько стоит аренда?",
+	"create.ask_private": "Закрытый или открытый матч?",
+
+	"search.ask_near": "Поделитесь геолокацией, чтобы найти матчи рядом",
+	"search.near":     "📍 Ищу матчи рядом с вами",
+
+	"create.ask_confirm":     "Организовать матч?",
+	"create.organize_anyway": "Организовать всё равно",
+
+	"reschedule.no_slots": "😥 Свободного времени на площадке рядом не нашлось",
+	"reschedule.ask":      "На какое время перенести матч #%d?",
+	"reschedule.busy":     "⛔️ Площадка уже занята в это время",
+	"reschedule.done":     "🕖 Матч #%d перенесен на %s",
+
+	"venue.policy_warn_set":  "Пересекающиеся матчи на площадке будут с предупреждением",
+	"venue.policy_block_set": "Пересекающиеся матчи на площадке запрещены",
+	"venue.policy_block":     "⛔️ Пересечения запрещены",
+	"venue.policy_warn":      "⚠️ Пересечения с предупреждением",
+
+	"conflict.warn":       "⚠️ В это время площадка уже занята:",
+	"conflict.block":      "⛔️ В это время площадка уже занята, пересечения запрещены:",
+	"conflict.match":      "Матч #%d %s - %s",
+	"conflict.free_slots": "Ближайшее свободное время:",
+
+	"day.week": "неделя",
+
+	"listing.title":       "Матч #%d",
+	"listing.start":       "Начало %s",
+	"listing.price":       "%d тг/чел",
+	"matches.places_left": "Осталось %d место|Осталось %d места|Осталось %d мест",
+	"matches.distance":    " - 📍 %.1f км",
+
+	"search.ask_location": "Отправьте название или часть адреса площадки",
+	"search.title":        "🔜 Ближайшие матчи: %s\n\t%s",
+	"search.filters":      "Фильтры",
+
+	"filter.max_price":     "💰 до %dтг",
+	"filter.min_places":    "🏃‍♂️ от %d места|🏃‍♂️ от %d мест|🏃‍♂️ от %d мест",
+	"filter.radius":        "🧭 в радиусе %.0f км",
+	"filter.none":          "Без фильтров",
+	"filter.nearest_first": "⬆️ Сначала ближайшие",
+	"filter.latest_first":  "⬇️ Сначала дальние",
+	"filter.today":         "Сегодня",
+	"filter.tomorrow":      "Завтра",
+	"filter.week":          "Неделя",
+	"filter.any_day":       "Любой день",
+	"filter.price_option":  "до %dтг",
+	"filter.any_price":     "Любая цена",
+	"filter.places_option": "от %d места|от %d мест|от %d мест",
+	"filter.any_places":    "Любые",
+	"filter.any_format":    "Любой",
+	"filter.location":      "📍 Место",
+	"filter.near":          "🧭 Рядом",
+	"filter.reset":         "♻️ Сбросить",
+	"filter.any_gender":    "Все",
+	"filter.eligible":      "🙋 Мне подходят",
+
+	"roster.reliability":  "🛡 Надёжность %d%% (✅ %d, ❌ %d, ⏰ %d)",
+	"roster.came":         "✅ Пришёл",
+	"roster.missed":       "❌ Не пришёл",
+	"reliability.option":  "от %d%%",
+	"reliability.any":     "Без ограничений",
+	"reliability.ask":     "🛡 Кто может записаться на матч сам?",
+	"reliability.set":     "🛡 Записаться смогут игроки с надёжностью от %d%%",
+	"reliability.set_any": "🛡 Записаться может любой игрок",
+
+	"rating.beginner":     "Новичок",
+	"rating.intermediate": "Средний",
+	"rating.advanced":     "Сильный",
+	"rating.pro":          "Профи",
+	"rating.saved":        "Уровень сохранен",
+
+	"gender.female": "Женщины",
+	"gender.male":   "Мужчины",
+
+	"eligibility.level_range":  "%s – %s",
+	"eligibility.level_min":    "%s+",
+	"eligibility.level_max":    "до %s",
+	"eligibility.age_range":    "%d–%d лет",
+	"eligibility.age_min":      "%d+ лет",
+	"eligibility.age_max":      "до %d лет",
+	"eligibility.any_level":    "Любой уровень",
+	"eligibility.any_gender":   "Все",
+	"eligibility.any_age":      "Любой возраст",
+	"eligibility.none":         "играть могут все",
+	"eligibility.ask":          "🎯 Кто может записаться: %s\n\nРяды: минимальный уровень, максимальный уровень, пол, минимальный возраст, максимальный возраст",
+	"eligibility.invalid":      "Нижняя граница не может быть выше верхней",
+	"eligibility.unmet_level":  "🎯 Ваш уровень не подходит для матча: %s. Попросите организатора добавить вас",
+	"eligibility.unmet_gender": "🎯 Матч только для: %s",
+	"eligibility.unmet_age":    "🎯 Матч только для: %s. Если вы не указали год рождения, сделайте это в /profile",
+
+	"profile.show":               "👤 Ваш профиль\nПол: %s\nГод рождения: %s\n\nМатчи могут быть ограничены по полу и возрасту, организатор не видит ни то, ни другое",
+	"profile.unknown":            "не указан",
+	"profile.female":             "Женский",
+	"profile.male":               "Мужской",
+	"profile.birth_year":         "🎂 Указать год рождения",
+	"profile.ask_birth_year":     "В каком году вы родились? Например, 1995",
+	"profile.invalid_birth_year": "Это не похоже на год рождения, отправьте четыре цифры, например 1995",
+
+	"chat.open":       "💬 Чат матча %d. Всё, что вы напишете, получат остальные игроки и организатор. Чтобы ответить на сообщение, используйте «Ответить»",
+	"chat.leave":      "🚪 Выйти из чата",
+	"chat.left":       "Вы вышли из чата матча. Ответы на его сообщения всё равно дойдут до игроков",
+	"chat.mute":       "🔕 Отключить чат",
+	"chat.unmute":     "🔔 Включить чат",
+	"chat.muted":      "🔕 Вы не будете получать сообщения из чата матча %d",
+	"chat.unmuted":    "🔔 Вы снова будете получать сообщения из чата матча %d",
+	"chat.message":    "💬 Матч %d · %s:\n%s",
+	"chat.text_only":  "В чат матча можно отправлять только текст",
+	"chat.not_member": "Вы не участвуете в матче %d и не можете писать в его чат",
+	"chat.too_fast":   "Вы пишете слишком часто, подождите минуту",
+	"chat.nobody":     "В чате этого матча пока больше никого нет",
+
+	"group.how_to":        "👥 К матчу %[1]d не привязана группа. Добавьте бота в свою группу администратором с правом приглашать и блокировать участников и отправьте там:\n/link_chat %[1]d",
+	"group.linked":        "👥 Матч %d привязан к группе «%s». Игроки получают личную ссылку при записи и удаляются из группы, когда уходят",
+	"group.unlink":        "🔗 Отвязать",
+	"group.unlinked":      "👥 Матч %d больше не привязан к «%s», ссылки-приглашения отозваны",
+	"group.only_in_group": "Отправьте эту команду в группе, которую хотите привязать",
+	"group.usage":         "Отправьте /link_chat и номер матча, например /link_chat 12",
+	"group.not_organizer": "Привязать группу к матчу %d может только его организатор",
+	"group.no_rights":     "Сначала сделайте бота администратором с правом приглашать и блокировать участников",
+	"group.not_admin":     "Привязать группу к матчу может только её администратор",
+	"group.linked_here":   "👥 Группа привязана к матчу %d. Игроки матча получат ссылки-приглашения",
+	"group.invite":        "👥 У матча %d есть группа «%s». Вступите по личной ссылке",
+	"group.join":          "Вступить в группу",
+
+	"balance.already": "⚖️ Команды уже сбалансированы",
+	"balance.preview": "⚖️ Предлагаемые составы:",
+	"balance.apply":   "✅ Применить",
+	"balance.applied": "✅ Составы обновлены, перемещено игроков: %d",
+	"team.moved":      "🔀 Вас перевели в команду %s в матче #%d",
+
+	"signout.late_warning": "⚠️ Бесплатно отменить участие можно было за %d ч. до начала матча.\n\t" +
+		"Если уйдёте без замены, вы должны будете оплатить свою долю %dтг, а это снизит вашу надёжность",
+	"signout.find_replacement": "🔁 Найти замену",
+	"signout.anyway":           "Всё равно отменить",
+	"signout.stay":             "Остаться",
+	"signout.done":             "Вы отменили участие в матче",
+	"signout.organizer":        "%s отменил участие в матче %d",
+	"signout.liable":           "С вас причитается %dтг за позднюю отмену",
+	"signout.organizer_late":   "%s отменил участие в матче %d после дедлайна, за ним долг %dтг",
+
+	"cancel_window.option":          "%d ч.",
+	"cancel_window.until_start":     "До начала",
+	"cancel_window.ask":             "⏳ За сколько часов до начала игроки могут отменить участие без штрафа?",
+	"cancel_window.set":             "⏳ Бесплатная отмена участия — не позже чем за %d ч. до начала",
+	"cancel_window.set_until_start": "⏳ Бесплатная отмена участия — до начала матча",
+
+	"team.rename":        "✏️ Название",
+	"team.badge":         "🎨 Значок",
+	"team.club":          "🏷 Клуб",
+	"team.ask_name":      "Отправьте название команды. Оно сохранится как клуб, и его можно будет выбрать в следующих матчах",
+	"team.name_too_long": "Название должно быть не длиннее 32 символов",
+	"team.ask_badge":     "Выберите значок команды",
+	"team.renamed":       "Команда теперь называется %s",
+	"club.none":          "У вас пока нет клубов. Переименуйте команду, и название сохранится как клуб",
+	"club.ask":           "Выберите клуб",
+
+	"report.ask_content":       "Отправьте текст, фото, видео или документ",
+	"report.added":             "📎 Добавлено в отчет. Отправьте еще или опубликуйте его",
+	"report.publish":           "📤 Опубликовать",
+	"report.already_published": "Этот отчет уже опубликован",
+	"report.empty":             "Отчет пуст",
+	"report.published":         "Отчет отправлен участникам",
+	"report.none":              "По этому матчу еще нет отчетов",
+	"report.title":             "📰 Отчет по матчу #%d от %s",
+
+	"result.request":      "🏁 Матч #%d завершился. Запишите результат",
+	"result.record":       "📝 Записать счёт",
+	"result.ask":          "Отправьте счёт через пробел в порядке команд: %s\n\tНапример: 3 2",
+	"result.not_numbers":  "Счёт должен состоять из чисел, например: 3 2",
+	"result.not_finished": "Матч ещё не закончился, запишите счёт после него",
+	"result.each_team":    "Укажите счёт каждой команды, например: 3 2",
+	"result.notice":       "🏁 Матч #%d: %s",
+	"result.ask_scorers":  "Отметьте авторов голов и лучшего игрока матча",
+	"result.done_hint":    "Когда закончите, откройте карточку матча",
+	"result.goal_added":   "Гол засчитан",
+	"result.goal_removed": "Гол отменен",
+	"result.mvp_set":      "Лучший игрок выбран",
+
+	"stats.none":  "📊 У вас пока нет сыгранных матчей с результатом",
+	"stats.title": "📊 Ваша статистика",
+	"stats.sport": "\n🏆 %s\nИгр: %d | Побед: %d | Ничьих: %d | Поражений: %d\n⚽️ Голов: %d | ⭐️ MVP: %d\n",
+
+	"signup.no_places":  "😥 Свободных мест не осталось",
+	"signup.any_team":   "🎲 Любая",
+	"signup.ask_team":   "В какую команду записаться?",
+	"signup.unreliable": "🛡 Организатор открыл запись только игрокам с надёжностью от %d%%. Попросите его добавить вас",
+	"signup.conflict":   "В этой команде уже нет мест или вы уже записаны на матч",
+	"signup.done":       "Вы записались на матч",
+	"signup.organizer":  "%s записался на матч %d",
+
+	"switch.no_places":          "В других командах нет свободных мест",
+	"switch.ask_team":           "В какую команду хотите перейти?",
+	"switch.request":            "%s хочет перейти в команду %s в матче #%d",
+	"switch.approve":            "✅ Перевести",
+	"switch.reject":             "❌ Отклонить",
+	"switch.requested":          "Запрос отправлен организатору",
+	"switch.rejected":           "Организатор отклонил ваш переход в другую команду в матче #%d",
+	"switch.rejected_organizer": "Запрос отклонен",
+
+	"move.no_places":    "В этой команде нет свободных мест",
+	"move.not_in_match": "Игрок больше не записан на матч",
+	"move.done":         "%s переведен в команду %s",
+
+	"transfer.ask": "Отправьте @username игрока, которому хотите передать место, или перешлите его сообщение.\n\t" +
+		"Он должен был хотя бы раз написать боту",
+	"transfer.ask_short":      "Отправьте @username или перешлите сообщение игрока",
+	"transfer.unknown_player": "Этот игрок еще не писал боту. Попросите его нажать /start и попробуйте снова",
+	"transfer.already_in":     "Этот игрок уже записан на матч",
+	"transfer.not_in_match":   "Вы не записаны на этот матч",
+	"transfer.offer":          "%s предлагает вам своё место в матче",
+	"transfer.accept":         "✅ Принять",
+	"transfer.decline":        "❌ Отказаться",
+	"transfer.offered":        "Предложение отправлено %s. Вы остаётесь в составе, пока он не примет его",
+	"transfer.expired":        "Это предложение больше не действует",
+	"transfer.accepted":       "Вы заняли место в матче #%d",
+	"transfer.accepted_from":  "%s принял ваше место в матче #%d",
+	"transfer.organizer":      "%s передал место в матче %d игроку %s",
+	"transfer.declined":       "Вы отказались от места",
+	"transfer.declined_from":  "%s отказался от вашего места в матче #%d",
+
+	"expense.rent":           "🏟 Аренда",
+	"expense.balls":          "⚽️ Мячи",
+	"expense.water":          "💧 Вода",
+	"expense.referee":        "🧑‍⚖️ Судья",
+	"expense.other":          "📦 Другое",
+	"expenses.send_split":    "🧮 Разослать расчет",
+	"expenses.clear":         "🗑 Очистить",
+	"expenses.ask_amount":    "%s: сколько потрачено, в тенге?",
+	"expenses.not_number":    "Отправьте сумму числом, например: 3000",
+	"expenses.split":         "💰 Расчет по матчу #%d\nОбщие расходы: %dтг\nВаша доля: %dтг\nВы оплатили: %dтг\n%s",
+	"expenses.title":         "💰 Расходы по матчу #%d",
+	"expenses.declared_rent": "%s (заявленная): %dтг",
+	"expenses.total":         "Итого: %dтг",
+	"expenses.players":       "Игроков: %d",
+	"match.no_players":       "В матче нет игроков",
+	"balance.refund":         "✅ Вам вернут %dтг",
+	"balance.owe":            "❗️ Нужно доплатить %dтг",
+	"balance.settled":        "👌 Все оплачено",
+
+	"pricing.capacity":            "Аренда на все места",
+	"pricing.signed_up":           "Аренда на записавшихся",
+	"pricing.attended":            "Аренда на пришедших",
+	"pricing.fixed":               "Фиксированная цена",
+	"pricing.free":                "Бесплатно",
+	"pricing.ask":                 "🏷 Как считать цену для игроков?\nСейчас: %dтг с человека",
+	"pricing.ask_fixed":           "Сколько платит каждый игрок, в тенге?",
+	"pricing.not_number":          "Отправьте цену числом, например: 2000",
+	"pricing.updated":             "🏷 Цена обновлена: %s",
+	"discount.title":              "🎟 Скидки игрокам",
+	"discount.none":               "без скидки",
+	"discount.free":               "бесплатно",
+	"notify.settings":             "🔔 Уведомления\nДоставка: %s\nТихие часы: %s\nЧасовой пояс: %s\n\nНажмите на вид уведомлений, чтобы включить или выключить его. Отложенные уведомления приходят вместе, с одним звуком",
+	"notify.instant":              "сразу",
+	"notify.digest_mode":          "сводка раз в час",
+	"notify.switch_digest":        "📬 Присылать сводкой раз в час",
+	"notify.switch_instant":       "⚡️ Присылать сразу",
+	"notify.quiet_off":            "нет",
+	"notify.quiet_range":          "%02d:00–%02d:00",
+	"notify.utc_offset":           "UTC%+d",
+	"notify.digest":               "📬 %d уведомление, пока вас не было|📬 %d уведомления, пока вас не было|📬 %d уведомлений, пока вас не было",
+	"notify.kind.signup":          "Записи",
+	"notify.kind.signout":         "Отписки",
+	"notify.kind.confirm":         "Подтверждения",
+	"notify.kind.payment":         "Оплаты",
+	"notify.kind.match":           "Изменения матча",
+	"notify.kind.invitation":      "Приглашения",
+	"notify.kind.report":          "Отчёты",
+	"notify.kind.chat":            "Чат матча",
+	"reach.unreachable":           "⚠️ Бот не может написать этим игрокам, сообщите им другим способом:\n%s",
+	"reach.player":                "• %s — %s",
+	"reach.reason.blocked":        "заблокировал бота",
+	"reach.reason.chat_not_found": "не запускал бота",
+	"reach.reason.deactivated":    "удалил аккаунт",
+}
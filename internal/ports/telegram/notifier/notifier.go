@@ -0,0 +1,116 @@
+// Package notifier delivers the messages the bot sends users about what
+// others did, following each user's notification settings. Replies to the
+// user's own actions are sent directly and don't go through it.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/outbox"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/service/match"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+)
+
+type Notifier interface {
+	// Notify queues the message to the user, or holds it for their digest
+	// or until their quiet hours are over. It reports whether the message
+	// was queued: false when it was held or muted, or when the bot can't
+	// write to the user.
+	Notify(user *entity.User, kind enum.NotificationKind, msg tgbotapi.MessageConfig) bool
+	// NotifyThen is Notify calling sent with the message once Telegram
+	// took it.
+	NotifyThen(user *entity.User, kind enum.NotificationKind, msg tgbotapi.MessageConfig, sent func(tgbotapi.Message)) bool
+	// Flush delivers the held notifications that are due.
+	Flush()
+}
+
+type notifier struct {
+	bot     *outbox.Bot
+	service match.Service
+}
+
+func New(bot *outbox.Bot, service match.Service) Notifier {
+	return &notifier{bot: bot, service: service}
+}
+
+func (n *notifier) Notify(user *entity.User, kind enum.NotificationKind, msg tgbotapi.MessageConfig) bool {
+	return n.NotifyThen(user, kind, msg, nil)
+}
+
+func (n *notifier) NotifyThen(user *entity.User, kind enum.NotificationKind, msg tgbotapi.MessageConfig, sent func(tgbotapi.Message)) bool {
+	if user.Unreachable != "" {
+		return false
+	}
+	settings, err := n.service.GetNotificationSettings(context.Background(), user.ID)
+	if err != nil {
+		// better a notification the user didn't want than a lost one
+		log.Println(err)
+		settings = &entity.NotificationSettings{UserID: user.ID}
+	}
+	if !settings.Enabled(kind) {
+		return false
+	}
+	if settings.Digest || settings.Quiet(time.Now()) {
+		n.hold(user, kind, msg)
+		return false
+	}
+	msg.ChatID = user.ChatID
+	n.bot.Deliver(kind, msg, sent)
+	return true
+}
+
+func (n *notifier) hold(user *entity.User, kind enum.NotificationKind, msg tgbotapi.MessageConfig) {
+	pending := &entity.PendingNotification{UserID: user.ID, Kind: kind, Text: msg.Text, ParseMode: msg.ParseMode}
+	if keyboard, ok := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup); ok {
+		markup, err := json.Marshal(keyboard)
+		if err != nil {
+			log.Println(err)
+		}
+		pending.ReplyMarkup = markup
+	}
+	if err := n.service.HoldNotification(context.Background(), pending); err != nil {
+		log.Println(err)
+	}
+}
+
+// Flush sends every user with due notifications a digest header, the only
+// message of the digest that makes a sound, followed by the held messages
+// in the order they were held.
+func (n *notifier) Flush() {
+	digests, err := n.service.DueDigests(context.Background(), time.Now())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, digest := range digests {
+		chatID := digest.User.ChatID
+		n.bot.Send(tgbotapi.NewMessage(chatID, i18n.N(view.Lang(digest.User), "notify.digest",
+			int64(len(digest.Notifications)), len(digest.Notifications))))
+		for _, pending := range digest.Notifications {
+			msg := tgbotapi.NewMessage(chatID, pending.Text)
+			msg.ParseMode = pending.ParseMode
+			msg.DisableNotification = true
+			if pending.ReplyMarkup != nil {
+				var keyboard tgbotapi.InlineKeyboardMarkup
+				if err := json.Unmarshal(pending.ReplyMarkup, &keyboard); err != nil {
+					log.Println(err)
+				} else {
+					msg.ReplyMarkup = keyboard
+				}
+			}
+			n.bot.Deliver(pending.Kind, msg, nil)
+		}
+		ids := lo.Map(digest.Notifications, func(p *entity.PendingNotification, _ int) int64 { return p.ID })
+		if err := n.service.DeletePendingNotifications(context.Background(), ids); err != nil {
+			log.Println(err)
+		}
+	}
+}
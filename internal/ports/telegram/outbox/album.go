@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// AlbumSize is the largest number of files Telegram groups in one album.
+const AlbumSize = 10
+
+// Album is the request sending the attachments together: a single file on
+// its own, several as a media group. It is nil without attachments.
+func Album(chatID int64, attachments []*entity.Attachment) tgbotapi.Chattable {
+	if len(attachments) == 1 {
+		attachment := attachments[0]
+		file := tgbotapi.FileID(attachment.FileID)
+		switch attachment.Kind {
+		case enum.AttachmentKindPhoto:
+			return tgbotapi.NewPhoto(chatID, file)
+		case enum.AttachmentKindVideo:
+			return tgbotapi.NewVideo(chatID, file)
+		case enum.AttachmentKindDocument:
+			return tgbotapi.NewDocument(chatID, file)
+		}
+		return nil
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+	files := make([]interface{}, 0, len(attachments))
+	for _, attachment := range attachments {
+		file := tgbotapi.FileID(attachment.FileID)
+		switch attachment.Kind {
+		case enum.AttachmentKindPhoto:
+			files = append(files, tgbotapi.NewInputMediaPhoto(file))
+		case enum.AttachmentKindVideo:
+			files = append(files, tgbotapi.NewInputMediaVideo(file))
+		case enum.AttachmentKindDocument:
+			files = append(files, tgbotapi.NewInputMediaDocument(file))
+		}
+	}
+	return tgbotapi.NewMediaGroup(chatID, files)
+}
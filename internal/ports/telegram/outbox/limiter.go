@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"math"
+	"time"
+)
+
+// bucket is a token bucket that lends tokens: taking one from an empty
+// bucket tells how long to wait until it would have been there.
+type bucket struct {
+	// rate is the number of tokens added per second.
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newBucket(rate, capacity float64, now time.Time) *bucket {
+	return &bucket{rate: rate, capacity: capacity, tokens: capacity, last: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// take takes a token and returns how long to wait before using it.
+func (b *bucket) take(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// full reports whether the bucket has refilled, so dropping it loses
+// nothing.
+func (b *bucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.capacity
+}
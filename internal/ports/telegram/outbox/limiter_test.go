@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketTake(t *testing.T) {
+	type take struct {
+		// at is the time of the take since the bucket was made.
+		at   time.Duration
+		want time.Duration
+	}
+	tests := []struct {
+		name           string
+		rate, capacity float64
+		takes          []take
+	}{
+		{
+			name: "burst within the capacity", rate: 1, capacity: 3,
+			takes: []take{{0, 0}, {0, 0}, {0, 0}},
+		},
+		{
+			name: "burst over the capacity", rate: 1, capacity: 2,
+			takes: []take{{0, 0}, {0, 0}, {0, time.Second}, {0, 2 * time.Second}},
+		},
+		{
+			name: "refills over time", rate: 1, capacity: 1,
+			takes: []take{{0, 0}, {time.Second, 0}, {1500 * time.Millisecond, 500 * time.Millisecond}},
+		},
+		{
+			name: "waits add up until refilled", rate: 1, capacity: 1,
+			takes: []take{{0, 0}, {0, time.Second}, {0, 2 * time.Second}, {3 * time.Second, 0}},
+		},
+		{
+			name: "refill is capped", rate: 1, capacity: 2,
+			takes: []take{{0, 0}, {time.Hour, 0}, {time.Hour, 0}, {time.Hour, time.Second}},
+		},
+		{
+			name: "slow rate of a group", rate: groupRate, capacity: 1,
+			takes: []take{{0, 0}, {0, 3 * time.Second}, {time.Second, 5 * time.Second}},
+		},
+	}
+	start := time.Date(2023, time.July, 21, 12, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBucket(tt.rate, tt.capacity, start)
+			for i, take := range tt.takes {
+				if got := b.take(start.Add(take.at)); got != take.want {
+					t.Fatalf("take %d at %s = %s, want %s", i+1, take.at, got, take.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBucketFull(t *testing.T) {
+	start := time.Date(2023, time.July, 21, 12, 0, 0, 0, time.UTC)
+	b := newBucket(1, 2, start)
+	if !b.full(start) {
+		t.Error("a new bucket isn't full")
+	}
+	b.take(start)
+	if b.full(start.Add(500 * time.Millisecond)) {
+		t.Error("bucket full before it refilled")
+	}
+	if !b.full(start.Add(time.Second)) {
+		t.Error("bucket not full once it refilled")
+	}
+}
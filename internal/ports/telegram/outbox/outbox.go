@@ -0,0 +1,222 @@
+// Package outbox is the way out of the bot to Telegram. It spreads the
+// requests to stay within Telegram's limits, waits out its flood control
+// and flags the users it can't write to. Replies are sent right away by the
+// goroutine handling the update, notifications are queued: workers send
+// them in order for each chat, record their delivery and send again later
+// the ones that didn't get through.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/service/match"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// The limits Telegram asks bots to keep to: 30 messages a second overall,
+// about one a second in a private chat and 20 a minute in a group. The
+// chat buckets allow short bursts so that a reply of a few messages isn't
+// slowed down.
+const (
+	globalRate  = 30
+	globalBurst = 30
+	privateRate = 1
+	groupRate   = 20.0 / 60
+	chatBurst   = 5
+)
+
+const (
+	// floodAttempts is how many times a request is made right away when
+	// Telegram answers with a retry_after.
+	floodAttempts = 3
+	// deliveryAttempts is how many times a notification is sent before it
+	// is given up on.
+	deliveryAttempts = 5
+	// retryBackoff is the wait before the second attempt of a notification,
+	// doubling with every next one.
+	retryBackoff = time.Minute
+	retryBatch   = 100
+	// workers is how many goroutines send the queued notifications, each
+	// serving its share of the chats so that a chat gets them in order.
+	workers = 8
+	// queueSize is how many notifications a worker holds, the next ones
+	// are left to Retry.
+	queueSize = 1000
+	// leaseTimeout is how long a queued notification is left to its worker
+	// before Retry takes it for lost, as when the bot stopped meanwhile.
+	leaseTimeout = 10 * time.Minute
+	// maxChatBuckets is how many chat buckets are kept before the refilled
+	// ones are dropped.
+	maxChatBuckets = 10000
+)
+
+// Bot is the Telegram client with Send and Request rate limited. Requests
+// made by the other methods of the embedded client are not.
+type Bot struct {
+	*tgbotapi.BotAPI
+	service match.Service
+
+	mu     sync.Mutex
+	global *bucket
+	chats  map[int64]*bucket
+
+	queues []chan *job
+}
+
+func New(api *tgbotapi.BotAPI, service match.Service) *Bot {
+	queues := make([]chan *job, workers)
+	for i := range queues {
+		queues[i] = make(chan *job, queueSize)
+	}
+	return &Bot{
+		BotAPI:  api,
+		service: service,
+		global:  newBucket(globalRate, globalBurst, time.Now()),
+		chats:   map[int64]*bucket{},
+		queues:  queues,
+	}
+}
+
+// Request makes the request once the limits allow it, retrying it when
+// Telegram asks to wait. A refusal showing the bot can't write to a user
+// flags them as unreachable.
+func (b *Bot) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	chatID := chatOf(c)
+	for attempt := 1; ; attempt++ {
+		b.wait(chatID)
+		resp, err := b.BotAPI.Request(c)
+		if delay, ok := floodWait(err, attempt); ok {
+			time.Sleep(delay)
+			continue
+		}
+		if reason := unreachable(err); reason != "" && chatID > 0 {
+			if err := b.service.MarkUnreachable(context.Background(), chatID, reason); err != nil {
+				log.Println(err)
+			}
+		}
+		return resp, err
+	}
+}
+
+func (b *Bot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	resp, err := b.Request(c)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+	var message tgbotapi.Message
+	err = json.Unmarshal(resp.Result, &message)
+	return message, err
+}
+
+func (b *Bot) SendMediaGroup(config tgbotapi.MediaGroupConfig) ([]tgbotapi.Message, error) {
+	resp, err := b.Request(config)
+	if err != nil {
+		return nil, err
+	}
+	var messages []tgbotapi.Message
+	err = json.Unmarshal(resp.Result, &messages)
+	return messages, err
+}
+
+// wait blocks until both the global and the chat limits let one more
+// request through.
+func (b *Bot) wait(chatID int64) {
+	now := time.Now()
+	b.mu.Lock()
+	delay := b.global.take(now)
+	if chatID != 0 {
+		chat, ok := b.chats[chatID]
+		if !ok {
+			if len(b.chats) >= maxChatBuckets {
+				for id, c := range b.chats {
+					if c.full(now) {
+						delete(b.chats, id)
+					}
+				}
+			}
+			rate := float64(privateRate)
+			if chatID < 0 {
+				rate = groupRate
+			}
+			chat = newBucket(rate, chatBurst, now)
+			b.chats[chatID] = chat
+		}
+		if d := chat.take(now); d > delay {
+			delay = d
+		}
+	}
+	b.mu.Unlock()
+	time.Sleep(delay)
+}
+
+// floodWait tells how long to wait before making the request again when
+// Telegram's flood control refused its attempt, false once it has been
+// made floodAttempts times or when it failed otherwise.
+func floodWait(err error, attempt int) (time.Duration, bool) {
+	var apiErr *tgbotapi.Error
+	if attempt >= floodAttempts || !errors.As(err, &apiErr) || apiErr.RetryAfter <= 0 {
+		return 0, false
+	}
+	return time.Duration(apiErr.RetryAfter) * time.Second, true
+}
+
+// chatOf returns the chat the request sends to, zero for requests that
+// only count against the global limit.
+func chatOf(c tgbotapi.Chattable) int64 {
+	switch c := c.(type) {
+	case tgbotapi.MessageConfig:
+		return c.ChatID
+	case tgbotapi.PhotoConfig:
+		return c.ChatID
+	case tgbotapi.VideoConfig:
+		return c.ChatID
+	case tgbotapi.DocumentConfig:
+		return c.ChatID
+	case tgbotapi.VenueConfig:
+		return c.ChatID
+	case tgbotapi.MediaGroupConfig:
+		return c.ChatID
+	case tgbotapi.EditMessageTextConfig:
+		return c.ChatID
+	case tgbotapi.EditMessageReplyMarkupConfig:
+		return c.ChatID
+	}
+	return 0
+}
+
+// unreachable tells why the refusal shows the bot can't write to the
+// private chat, empty when it doesn't.
+func unreachable(err error) enum.UnreachableReason {
+	var apiErr *tgbotapi.Error
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	message := strings.ToLower(apiErr.Message)
+	switch {
+	case apiErr.Code == http.StatusForbidden && strings.Contains(message, "blocked by the user"):
+		return enum.UnreachableBlocked
+	case apiErr.Code == http.StatusForbidden && strings.Contains(message, "deactivated"):
+		return enum.UnreachableDeactivated
+	case strings.Contains(message, "chat not found"), strings.Contains(message, "can't initiate conversation"):
+		return enum.UnreachableChatNotFound
+	}
+	return ""
+}
+
+// transient reports whether sending again may succeed: Telegram refusing
+// the message itself, like a blocked bot or a bad request, won't change.
+func transient(err error) bool {
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func apiError(code int, message string, retryAfter int) error {
+	return &tgbotapi.Error{Code: code, Message: message, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: retryAfter}}
+}
+
+func TestFloodWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		attempt int
+		want    time.Duration
+		ok      bool
+	}{
+		{name: "no error", attempt: 1},
+		{name: "network failure", err: errors.New("connection reset"), attempt: 1},
+		{name: "too many requests without retry_after", err: apiError(http.StatusTooManyRequests, "Too Many Requests", 0), attempt: 1},
+		{name: "retry_after", err: apiError(http.StatusTooManyRequests, "Too Many Requests: retry after 7", 7), attempt: 1,
+			want: 7 * time.Second, ok: true},
+		{name: "retry_after wrapped", err: fmt.Errorf("send: %w", apiError(http.StatusTooManyRequests, "Too Many Requests", 2)),
+			attempt: 2, want: 2 * time.Second, ok: true},
+		{name: "retry_after on the last attempt", err: apiError(http.StatusTooManyRequests, "Too Many Requests", 7),
+			attempt: floodAttempts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := floodWait(tt.err, tt.attempt)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("floodWait() = %s, %t, want %s, %t", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want enum.UnreachableReason
+	}{
+		{nil, ""},
+		{errors.New("connection reset"), ""},
+		{apiError(http.StatusForbidden, "Forbidden: bot was blocked by the user", 0), enum.UnreachableBlocked},
+		{apiError(http.StatusForbidden, "Forbidden: user is deactivated", 0), enum.UnreachableDeactivated},
+		{apiError(http.StatusBadRequest, "Bad Request: chat not found", 0), enum.UnreachableChatNotFound},
+		{apiError(http.StatusForbidden, "Forbidden: bot can't initiate conversation with a user", 0), enum.UnreachableChatNotFound},
+		{apiError(http.StatusForbidden, "Forbidden: bot was kicked from the group chat", 0), ""},
+		{apiError(http.StatusTooManyRequests, "Too Many Requests", 3), ""},
+	}
+	for _, tt := range tests {
+		if got := unreachable(tt.err); got != tt.want {
+			t.Errorf("unreachable(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
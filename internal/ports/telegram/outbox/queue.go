@@ -0,0 +1,189 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// request is a notification as it is kept for its attempts: a message or
+// an album of attachments.
+type request struct {
+	Message *tgbotapi.MessageConfig `json:"message,omitempty"`
+	ChatID  int64                   `json:"chat_id,omitempty"`
+	Album   []*entity.Attachment    `json:"album,omitempty"`
+}
+
+func (r *request) chatID() int64 {
+	if r.Message != nil {
+		return r.Message.ChatID
+	}
+	return r.ChatID
+}
+
+// send makes the request, returning the first message of an album.
+func (r *request) send(b *Bot) (tgbotapi.Message, error) {
+	if r.Message != nil {
+		return b.Send(*r.Message)
+	}
+	switch album := Album(r.ChatID, r.Album).(type) {
+	case nil:
+		return tgbotapi.Message{}, errors.New("empty album")
+	case tgbotapi.MediaGroupConfig:
+		messages, err := b.SendMediaGroup(album)
+		if err != nil || len(messages) == 0 {
+			return tgbotapi.Message{}, err
+		}
+		return messages[0], nil
+	default:
+		return b.Send(album)
+	}
+}
+
+// job is a queued attempt at a notification.
+type job struct {
+	delivery *entity.OutboundMessage
+	request  *request
+	// sent is called with the message once Telegram took it, nil when
+	// nobody waits for it.
+	sent func(tgbotapi.Message)
+}
+
+// Run sends the queued notifications until ctx is done. Those still queued
+// then are sent by Retry once their lease is over.
+func (b *Bot) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, queue := range b.queues {
+		queue := queue
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-queue:
+					b.attempt(j)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Deliver queues the notification and records its delivery to the
+// recipient. sent, when not nil, is called with the message once Telegram
+// took it. A notification Telegram didn't take because of its flood control,
+// its own failure or the network is sent again by Retry.
+func (b *Bot) Deliver(kind enum.NotificationKind, msg tgbotapi.MessageConfig, sent func(tgbotapi.Message)) {
+	b.enqueue(kind, &request{Message: &msg}, sent)
+}
+
+// DeliverAlbum queues the attachments to be sent together, as Album does,
+// the same way as Deliver.
+func (b *Bot) DeliverAlbum(kind enum.NotificationKind, chatID int64, attachments []*entity.Attachment) {
+	if len(attachments) == 0 {
+		return
+	}
+	b.enqueue(kind, &request{ChatID: chatID, Album: attachments}, nil)
+}
+
+// enqueue records the delivery as pending, leased to the worker of the
+// chat, and hands it the notification.
+func (b *Bot) enqueue(kind enum.NotificationKind, req *request, sent func(tgbotapi.Message)) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lease := time.Now().Add(leaseTimeout)
+	delivery := &entity.OutboundMessage{
+		ChatID: req.chatID(), Kind: kind, Status: enum.DeliveryStatusPending, Payload: payload, NextAttemptAt: &lease,
+	}
+	if err := b.service.CreateOutboundMessage(context.Background(), delivery); err != nil {
+		// better a notification without a record than a lost one
+		log.Println(err)
+	}
+	b.push(&job{delivery: delivery, request: req, sent: sent})
+}
+
+// push hands the job to the worker of its chat. When the worker is too far
+// behind, the delivery is left to the next Retry instead.
+func (b *Bot) push(j *job) {
+	select {
+	case b.queues[uint64(j.delivery.ChatID)%uint64(len(b.queues))] <- j:
+	default:
+		now := time.Now()
+		j.delivery.NextAttemptAt = &now
+		if err := b.service.UpdateOutboundMessage(context.Background(), j.delivery); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// attempt sends the queued notification and records how it went.
+func (b *Bot) attempt(j *job) {
+	j.delivery.Attempts++
+	sent, err := j.request.send(b)
+	settle(j.delivery, sent.MessageID, err, time.Now())
+	if err != nil {
+		log.Println(err)
+	} else if j.sent != nil {
+		j.sent(sent)
+	}
+	if err := b.service.UpdateOutboundMessage(context.Background(), j.delivery); err != nil {
+		log.Println(err)
+	}
+}
+
+// Retry queues again the notifications whose next attempt is due.
+func (b *Bot) Retry() {
+	due, err := b.service.GetDueOutboundMessages(context.Background(), time.Now(), retryBatch)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, delivery := range due {
+		var req request
+		if err := json.Unmarshal(delivery.Payload, &req); err != nil {
+			log.Println(err)
+			delivery.Status, delivery.Error, delivery.Payload, delivery.NextAttemptAt = enum.DeliveryStatusFailed, err.Error(), nil, nil
+		} else {
+			lease := time.Now().Add(leaseTimeout)
+			delivery.NextAttemptAt = &lease
+		}
+		if err := b.service.UpdateOutboundMessage(context.Background(), delivery); err != nil {
+			// not leased, the next Retry would queue it once more
+			log.Println(err)
+			continue
+		}
+		if delivery.Status == enum.DeliveryStatusPending {
+			b.push(&job{delivery: delivery, request: &req})
+		}
+	}
+}
+
+// settle sets the status of the delivery after an attempt, keeping the
+// request for the next one when the failure may pass.
+func settle(delivery *entity.OutboundMessage, messageID int, err error, now time.Time) {
+	delivery.NextAttemptAt = nil
+	if err == nil {
+		id := int64(messageID)
+		delivery.Status, delivery.TelegramMessageID, delivery.Error, delivery.Payload = enum.DeliveryStatusSent, &id, "", nil
+		return
+	}
+	delivery.Status, delivery.Error = enum.DeliveryStatusFailed, err.Error()
+	if !transient(err) || delivery.Attempts >= deliveryAttempts {
+		delivery.Payload = nil
+		return
+	}
+	next := now.Add(retryBackoff << (delivery.Attempts - 1))
+	delivery.Status, delivery.NextAttemptAt = enum.DeliveryStatusPending, &next
+}
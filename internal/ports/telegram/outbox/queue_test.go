@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestSettle(t *testing.T) {
+	now := time.Date(2023, time.July, 21, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		attempts int64
+		err      error
+		status   enum.DeliveryStatus
+		// next is the wait before the next attempt, zero when there is none.
+		next time.Duration
+	}{
+		{name: "sent", attempts: 1, status: enum.DeliveryStatusSent},
+		{name: "sent on a retry", attempts: 3, status: enum.DeliveryStatusSent},
+		{name: "network failure", attempts: 1, err: errors.New("connection reset"),
+			status: enum.DeliveryStatusPending, next: time.Minute},
+		{name: "flood control", attempts: 2, err: apiError(http.StatusTooManyRequests, "Too Many Requests", 5),
+			status: enum.DeliveryStatusPending, next: 2 * time.Minute},
+		{name: "Telegram failure", attempts: 4, err: apiError(http.StatusBadGateway, "Bad Gateway", 0),
+			status: enum.DeliveryStatusPending, next: 8 * time.Minute},
+		{name: "out of attempts", attempts: deliveryAttempts, err: errors.New("connection reset"),
+			status: enum.DeliveryStatusFailed},
+		{name: "blocked", attempts: 1, err: apiError(http.StatusForbidden, "Forbidden: bot was blocked by the user", 0),
+			status: enum.DeliveryStatusFailed},
+		{name: "bad request", attempts: 1, err: apiError(http.StatusBadRequest, "Bad Request: message is too long", 0),
+			status: enum.DeliveryStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lease := now.Add(leaseTimeout)
+			payload := []byte(`{"message": {"chat_id": 7}}`)
+			delivery := &entity.OutboundMessage{ChatID: 7, Attempts: tt.attempts, Payload: payload, NextAttemptAt: &lease}
+			settle(delivery, 99, tt.err, now)
+			if delivery.Status != tt.status {
+				t.Errorf("status = %s, want %s", delivery.Status, tt.status)
+			}
+			switch tt.status {
+			case enum.DeliveryStatusSent:
+				if delivery.TelegramMessageID == nil || *delivery.TelegramMessageID != 99 || delivery.Error != "" {
+					t.Errorf("sent delivery has message %v and error %q", delivery.TelegramMessageID, delivery.Error)
+				}
+			default:
+				if delivery.Error != tt.err.Error() {
+					t.Errorf("error = %q, want %q", delivery.Error, tt.err.Error())
+				}
+			}
+			if tt.next == 0 {
+				if delivery.NextAttemptAt != nil || delivery.Payload != nil {
+					t.Errorf("delivery kept for a next attempt at %v", delivery.NextAttemptAt)
+				}
+				return
+			}
+			if delivery.NextAttemptAt == nil || !delivery.NextAttemptAt.Equal(now.Add(tt.next)) {
+				t.Errorf("next attempt at %v, want %s", delivery.NextAttemptAt, now.Add(tt.next))
+			}
+			if string(delivery.Payload) != string(payload) {
+				t.Errorf("payload = %s, want %s", delivery.Payload, payload)
+			}
+		})
+	}
+}
+
+// TestRequestPayload checks that a queued notification comes back from its
+// payload as the same request, so that Retry sends what was queued.
+func TestRequestPayload(t *testing.T) {
+	msg := tgbotapi.NewMessage(7, "Match #42 moved")
+	msg.ParseMode = tgbotapi.ModeHTML
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("More", "match_more-42"),
+	))
+	album := []*entity.Attachment{
+		{Kind: enum.AttachmentKindPhoto, FileID: "photo"},
+		{Kind: enum.AttachmentKindVideo, FileID: "video"},
+	}
+	tests := []struct {
+		name    string
+		request *request
+		chatID  int64
+		want    tgbotapi.Chattable
+	}{
+		{name: "message", request: &request{Message: &msg}, chatID: 7},
+		{name: "album", request: &request{ChatID: 7, Album: album}, chatID: 7,
+			want: tgbotapi.NewMediaGroup(7, []interface{}{
+				tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("photo")),
+				tgbotapi.NewInputMediaVideo(tgbotapi.FileID("video")),
+			})},
+		{name: "single file", request: &request{ChatID: -100, Album: album[:1]}, chatID: -100,
+			want: tgbotapi.NewPhoto(-100, tgbotapi.FileID("photo"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got request
+			if err := json.Unmarshal(payload, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.chatID() != tt.chatID {
+				t.Errorf("chat = %d, want %d", got.chatID(), tt.chatID)
+			}
+			if tt.request.Message != nil {
+				if got.Message == nil || got.Message.Text != msg.Text || got.Message.ParseMode != msg.ParseMode {
+					t.Errorf("message = %+v, want %+v", got.Message, msg)
+				}
+				if _, ok := got.Message.ReplyMarkup.(map[string]interface{}); !ok {
+					t.Errorf("keyboard of the message lost: %#v", got.Message.ReplyMarkup)
+				}
+				return
+			}
+			if album := Album(got.ChatID, got.Album); !reflect.DeepEqual(album, tt.want) {
+				t.Errorf("album = %#v, want %#v", album, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var reliabilityThresholds = []int64{0, 50, 70, 90}
+
+// showRoster shows the organizer the reliability of every player and, once
+// the match is over, lets them mark who showed up.
+func (r *router) showRoster(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	finished := time.Now().After(m.FinishAt)
+	for _, team := range m.Teams {
+		for _, member := range team.Members {
+			reliability, err := r.service.GetReliability(context.Background(), member.ID)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			msg := tgbotapi.NewMessage(telegramID, view.TeamLabel(team)+" "+member.DisplayName()+"\n"+r.t(telegramID, "roster.reliability",
+				reliability.Score(), reliability.Attended, reliability.NoShows, reliability.LateCancels))
+			if finished {
+				msg.ReplyMarkup = attendanceKeyboard(r.lang(telegramID), matchID, member)
+			}
+			r.bot.Send(msg)
+		}
+	}
+}
+
+func (r *router) setAttendance(callback *tgbotapi.CallbackQuery, callbacks []string, user *entity.User) {
+	if len(callbacks) < 4 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	attended := callbacks[3] == "yes"
+	if err := r.service.SetAttendance(context.Background(), matchID, memberID, attended); err != nil {
+		log.Println(err)
+		return
+	}
+	member := &entity.User{ID: memberID, Attended: &attended}
+	r.bot.Send(tgbotapi.NewEditMessageReplyMarkup(callback.From.ID, callback.Message.MessageID, attendanceKeyboard(r.lang(callback.From.ID), matchID, member)))
+}
+
+func (r *router) askMinReliability(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, threshold := range reliabilityThresholds {
+		label := r.t(telegramID, "reliability.option", threshold)
+		if threshold == 0 {
+			label = r.t(telegramID, "reliability.any")
+		}
+		current := m.MinReliability == nil && threshold == 0 || m.MinReliability != nil && *m.MinReliability == threshold
+		if current {
+			label = "✓ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_min_reliability-%d-%d", matchID, threshold)))
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "reliability.ask"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	r.bot.Send(msg)
+}
+
+func (r *router) setMinReliability(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	threshold, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	if err := r.service.SetMinReliability(context.Background(), matchID, threshold); err != nil {
+		log.Println(err)
+		return
+	}
+	text := r.t(telegramID, "reliability.set", threshold)
+	if threshold == 0 {
+		text = r.t(telegramID, "reliability.set_any")
+	}
+	msg := tgbotapi.NewMessage(telegramID, text)
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), matchID)
+	r.bot.Send(msg)
+}
+
+func attendanceKeyboard(lang i18n.Lang, matchID int64, member *entity.User) tgbotapi.InlineKeyboardMarkup {
+	came, missed := i18n.T(lang, "roster.came"), i18n.T(lang, "roster.missed")
+	if member.Attended != nil {
+		if *member.Attended {
+			came = "✓ " + came
+		} else {
+			missed = "✓ " + missed
+		}
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(came, fmt.Sprintf("attend-%d-%d-yes", matchID, member.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(missed, fmt.Sprintf("attend-%d-%d-no", matchID, member.ID)),
+		),
+	)
+}
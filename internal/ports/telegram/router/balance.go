@@ -0,0 +1,138 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// organizedMatch returns the match if the user organizes it.
+func (r *router) organizedMatch(matchID int64, user *entity.User) (*entity.Match, bool) {
+	m, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return nil, false
+	}
+	return m, m.OrganizerID == user.ID
+}
+
+// showRatings lets the organizer set the level of every player of the match.
+func (r *router) showRatings(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	for _, team := range m.Teams {
+		for _, member := range team.Members {
+			row := []tgbotapi.InlineKeyboardButton{}
+			for _, level := range view.Levels {
+				label := r.t(telegramID, level.Key)
+				if member.Rating == level.Rating {
+					label = "✓ " + label
+				}
+				row = append(row, tgbotapi.NewInlineKeyboardButtonData(label,
+					fmt.Sprintf("set_rating-%d-%d-%d", matchID, member.ID, level.Rating)))
+			}
+			msg := tgbotapi.NewMessage(telegramID, fmt.Sprintf("%s %s (%d)", view.TeamLabel(team), member.DisplayName(), member.Rating))
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+			r.bot.Send(msg)
+		}
+	}
+}
+
+func (r *router) setRating(callback *tgbotapi.CallbackQuery, callbacks []string, user *entity.User) {
+	if len(callbacks) < 4 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	rating, _ := strconv.ParseInt(callbacks[3], 10, 64)
+	m, ok := r.organizedMatch(matchID, user)
+	// the organizer rates the players of their match, nobody else
+	if !ok || m.TeamOf(memberID) == nil {
+		return
+	}
+	if err := r.service.SetPlayerRating(context.Background(), memberID, m.Type, rating); err != nil {
+		log.Println(err)
+		return
+	}
+	r.bot.Send(tgbotapi.NewCallback(callback.ID, r.t(callback.From.ID, "rating.saved")))
+}
+
+// previewBalance shows the organizer the balanced teams before applying them.
+func (r *router) previewBalance(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	assignment, err := r.service.PreviewBalancedTeams(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(assignment.Moves) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "balance.already")))
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "balance.preview")+"\n\n"+assignmentText(assignment))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "balance.apply"), fmt.Sprintf("balance_apply-%d", matchID)),
+		),
+	)
+	r.bot.Send(msg)
+}
+
+func (r *router) applyBalance(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	assignment, err := r.service.ApplyBalancedTeams(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	teams := make(map[int64]*entity.Team)
+	for _, team := range assignment.Teams {
+		teams[team.ID] = team
+	}
+	moved := make([]*entity.User, 0, len(assignment.Moves))
+	for _, move := range assignment.Moves {
+		moved = append(moved, move.User)
+		lang := view.Lang(move.User)
+		msg := tgbotapi.NewMessage(move.User.ChatID,
+			i18n.T(lang, "team.moved", view.TeamLabel(teams[move.ToTeamID]), matchID))
+		msg.ReplyMarkup = matchMoreKeyboard(lang, matchID)
+		r.notifier.Notify(move.User, enum.NotificationMatch, msg)
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "balance.applied", len(assignment.Moves)))
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), matchID)
+	r.bot.Send(msg)
+	r.reportUnreachable(telegramID, moved)
+}
+
+func assignmentText(assignment *entity.TeamAssignment) string {
+	moved := make(map[int64]bool)
+	for _, move := range assignment.Moves {
+		moved[move.User.ID] = true
+	}
+	out := ""
+	for _, team := range assignment.Teams {
+		out += fmt.Sprintf("%s Σ %d\n", view.TeamLabel(team), team.RatingSum())
+		for _, member := range team.Members {
+			mark := ""
+			if moved[member.ID] {
+				mark = " 🔀"
+			}
+			out += fmt.Sprintf("  %s (%d)%s\n", member.DisplayName(), member.Rating, mark)
+		}
+		out += "\n"
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var cancelWindows = []int64{0, 3, 12, 24, 48}
+
+// askSignOut signs the player out right away before the cancel deadline or
+// when they never confirmed, and warns about the penalty otherwise.
+func (r *router) askSignOut(telegramID, matchID int64, user *entity.User) {
+	match, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !match.LateCancel(user.ID, time.Now()) {
+		r.signOut(telegramID, match, user)
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "signout.late_warning",
+		match.CancelWindowHours, match.PriceFor(user.ID)))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "signout.find_replacement"), fmt.Sprintf("signout_replace-%d", matchID)),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "signout.anyway"), fmt.Sprintf("signout_confirm-%d", matchID)),
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "signout.stay"), fmt.Sprintf("get_match_by_id-%d", matchID)),
+		),
+	)
+	r.bot.Send(msg)
+}
+
+func (r *router) confirmSignOut(telegramID, matchID int64, user *entity.User) {
+	match, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.signOut(telegramID, match, user)
+}
+
+func (r *router) signOut(telegramID int64, match *entity.Match, user *entity.User) {
+	organizer, err := r.service.GetUserByID(context.Background(), match.OrganizerID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	cancellation, err := r.service.SignOutMatch(context.Background(), user.ID, match.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.removeFromMatchChat(match, user)
+	text, organizerText := r.t(telegramID, "signout.done"), i18n.T(view.Lang(organizer), "signout.organizer", user.DisplayName(), match.ID)
+	if cancellation.Late {
+		text += "\n" + r.t(telegramID, "signout.liable", cancellation.LiableAmount)
+		organizerText = i18n.T(view.Lang(organizer), "signout.organizer_late", user.DisplayName(), match.ID, cancellation.LiableAmount)
+	}
+	msg := tgbotapi.NewMessage(telegramID, text)
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), match.ID)
+	r.bot.Send(msg)
+	msg = tgbotapi.NewMessage(organizer.ChatID, organizerText)
+	msg.ReplyMarkup = matchMoreKeyboard(view.Lang(organizer), match.ID)
+	r.notifier.Notify(organizer, enum.NotificationSignOut, msg)
+}
+
+func (r *router) askCancelWindow(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, hours := range cancelWindows {
+		label := r.t(telegramID, "cancel_window.option", hours)
+		if hours == 0 {
+			label = r.t(telegramID, "cancel_window.until_start")
+		}
+		if m.CancelWindowHours == hours {
+			label = "✓ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_cancel_window-%d-%d", matchID, hours)))
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "cancel_window.ask"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	r.bot.Send(msg)
+}
+
+func (r *router) setCancelWindow(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	hours, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	if err := r.service.SetCancelWindow(context.Background(), matchID, hours); err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "cancel_window.set", hours))
+	if hours == 0 {
+		msg.Text = r.t(telegramID, "cancel_window.set_until_start")
+	}
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), matchID)
+	r.bot.Send(msg)
+}
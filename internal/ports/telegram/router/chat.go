@@ -0,0 +1,162 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// openChat makes every following message of the user go to the players of
+// the match until they leave the chat.
+func (r *router) openChat(telegramID, matchID int64, user *entity.User) {
+	muted, err := r.service.IsChatMuted(context.Background(), matchID, user.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetMatchID(telegramID, matchID)
+	r.userCache.SetStatus(telegramID, users.StatusMatchChat)
+	lang := r.lang(telegramID)
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "chat.open", matchID))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(muteButton(lang, matchID, muted)),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "chat.leave"), "chat_leave")),
+	)
+	r.bot.Send(msg)
+}
+
+func muteButton(lang i18n.Lang, matchID int64, muted bool) tgbotapi.InlineKeyboardButton {
+	if muted {
+		return tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "chat.unmute"), fmt.Sprintf("chat_mute-%d-off", matchID))
+	}
+	return tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "chat.mute"), fmt.Sprintf("chat_mute-%d-on", matchID))
+}
+
+func (r *router) leaveChat(telegramID int64) {
+	if r.userCache.GetStatus(telegramID) == users.StatusMatchChat {
+		r.userCache.SetStatus(telegramID, 0)
+	}
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "chat.left")))
+}
+
+// muteChat applies a "chat_mute-<matchID>-on|off" button press.
+func (r *router) muteChat(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	muted := callbacks[2] == "on"
+	if err := r.service.SetChatMuted(context.Background(), matchID, user.ID, muted); err != nil {
+		log.Println(err)
+		return
+	}
+	lang := r.lang(telegramID)
+	text := i18n.T(lang, "chat.unmuted", matchID)
+	if muted {
+		text = i18n.T(lang, "chat.muted", matchID)
+	}
+	msg := tgbotapi.NewMessage(telegramID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(muteButton(lang, matchID, muted)))
+	r.bot.Send(msg)
+}
+
+// chatMessage relays a message written in chat mode. A reply to a relayed
+// message goes to the match of that message, threaded under it, whether
+// the user is in chat mode or not; false means the message is not for a
+// match chat.
+func (r *router) chatMessage(msg *tgbotapi.Message, user *entity.User) bool {
+	if msg.ReplyToMessage != nil {
+		replyTo, err := r.service.GetChatMessageByCopy(context.Background(), msg.Chat.ID, msg.ReplyToMessage.MessageID)
+		if err != nil {
+			log.Println(err)
+			return false
+		}
+		if replyTo != nil {
+			r.relayChat(msg, user, replyTo.MatchID, replyTo)
+			return true
+		}
+	}
+	if r.userCache.GetStatus(msg.From.ID) != users.StatusMatchChat {
+		return false
+	}
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return false
+	}
+	r.relayChat(msg, user, cached.MatchID, nil)
+	return true
+}
+
+// relayChat sends the text of the message to the other players of the
+// match, as a reply to their copy of replyTo when it is set.
+func (r *router) relayChat(msg *tgbotapi.Message, user *entity.User, matchID int64, replyTo *entity.ChatMessage) {
+	if msg.Text == "" {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "chat.text_only")))
+		return
+	}
+	message := &entity.ChatMessage{MatchID: matchID, SenderID: user.ID, Text: msg.Text}
+	if replyTo != nil {
+		message.ReplyToID = &replyTo.ID
+	}
+	recipients, err := r.service.SendChatMessage(context.Background(), message)
+	switch errors.Type(err) {
+	case errors.Forbidden:
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "chat.not_member", matchID)))
+		return
+	case errors.Conflict:
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "chat.too_fast")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	// the original is recorded as well so that replies to it are threaded
+	// for the sender too
+	r.addChatCopy(message.ID, msg.Chat.ID, msg.MessageID)
+	threads := map[int64]int{}
+	if replyTo != nil {
+		copies, err := r.service.GetChatCopies(context.Background(), replyTo.ID)
+		if err != nil {
+			log.Println(err)
+		}
+		for _, c := range copies {
+			threads[c.ChatID] = c.TelegramMessageID
+		}
+	}
+	for _, recipient := range recipients {
+		chatID := recipient.ChatID
+		lang := view.Lang(recipient)
+		out := tgbotapi.NewMessage(chatID, i18n.T(lang, "chat.message", matchID, user.DisplayName(), msg.Text))
+		out.ReplyToMessageID = threads[chatID]
+		out.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(muteButton(lang, matchID, false)))
+		// held messages can't be threaded, they get no copy
+		r.notifier.NotifyThen(recipient, enum.NotificationChat, out, func(sent tgbotapi.Message) {
+			r.addChatCopy(message.ID, chatID, sent.MessageID)
+		})
+	}
+	if len(recipients) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "chat.nobody")))
+	}
+}
+
+func (r *router) addChatCopy(messageID, chatID int64, telegramMessageID int) {
+	err := r.service.AddChatCopy(context.Background(), &entity.ChatCopy{
+		MessageID:         messageID,
+		ChatID:            chatID,
+		TelegramMessageID: telegramMessageID,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,165 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const badgesPerRow = 7
+
+// showTeamsEditor lets the organizer rename the teams of the match and
+// change their badges.
+func (r *router) showTeamsEditor(telegramID, matchID int64, user *entity.User) {
+	match, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	for _, team := range match.Teams {
+		msg := tgbotapi.NewMessage(telegramID, view.TeamLabel(team))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "team.rename"), fmt.Sprintf("team_rename-%d", team.ID)),
+				tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "team.badge"), fmt.Sprintf("team_badge-%d", team.ID)),
+				tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "team.club"), fmt.Sprintf("team_club-%d", team.ID)),
+			),
+		)
+		r.bot.Send(msg)
+	}
+}
+
+// organizedTeam reports whether the user organizes the match of the team.
+func (r *router) organizedTeam(teamID int64, user *entity.User) bool {
+	matchID, err := r.service.GetMatchIDByTeamID(context.Background(), teamID)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+	_, ok := r.organizedMatch(matchID, user)
+	return ok
+}
+
+func (r *router) askTeamName(telegramID, teamID int64, user *entity.User) {
+	if !r.organizedTeam(teamID, user) {
+		return
+	}
+	r.userCache.SetTeamID(telegramID, teamID)
+	r.userCache.SetStatus(telegramID, users.StatusTeamName)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "team.ask_name")))
+}
+
+func (r *router) renameTeam(msg *tgbotapi.Message, user *entity.User) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	team, err := r.service.RenameTeam(context.Background(), user.ID, cached.TeamID, msg.Text)
+	if errors.Type(err) == errors.Invalid {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "team.name_too_long")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	r.sendTeamUpdated(msg.From.ID, team)
+}
+
+func (r *router) askTeamBadge(telegramID, teamID int64, user *entity.User) {
+	if !r.organizedTeam(teamID, user) {
+		return
+	}
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for i, badge := range view.TeamPalette {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(badge, fmt.Sprintf("team_set_badge-%d-%d", teamID, i)))
+		if len(row) == badgesPerRow {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) != 0 {
+		rows = append(rows, row)
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "team.ask_badge"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	r.bot.Send(msg)
+}
+
+func (r *router) setTeamBadge(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	teamID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	index, _ := strconv.Atoi(callbacks[2])
+	if index < 0 || index >= len(view.TeamPalette) || !r.organizedTeam(teamID, user) {
+		return
+	}
+	team, err := r.service.SetTeamBadge(context.Background(), teamID, view.TeamPalette[index])
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.sendTeamUpdated(telegramID, team)
+}
+
+func (r *router) askTeamClub(telegramID, teamID int64, user *entity.User) {
+	if !r.organizedTeam(teamID, user) {
+		return
+	}
+	clubs, err := r.service.GetClubs(context.Background(), user.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(clubs) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "club.none")))
+		return
+	}
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, club := range clubs {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(club.Emoji+" "+club.Name, fmt.Sprintf("team_set_club-%d-%d", teamID, club.ID)),
+		))
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "club.ask"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	r.bot.Send(msg)
+}
+
+func (r *router) setTeamClub(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	teamID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	clubID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if !r.organizedTeam(teamID, user) {
+		return
+	}
+	team, err := r.service.ApplyClub(context.Background(), user.ID, teamID, clubID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.sendTeamUpdated(telegramID, team)
+}
+
+func (r *router) sendTeamUpdated(telegramID int64, team *entity.Team) {
+	matchID, err := r.service.GetMatchIDByTeamID(context.Background(), team.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "team.renamed", view.TeamLabel(team)))
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), matchID)
+	r.bot.Send(msg)
+}
@@ -0,0 +1,177 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// askConfirmMatch asks the organizer to confirm the new match, warning
+// first if the venue is already booked at that time.
+func (r *router) askConfirmMatch(telegramID int64) {
+	match, err := r.cache.GetMatch(telegramID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	conflict, err := r.service.CheckVenueConflicts(context.Background(), match)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if conflict == nil {
+		msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "create.ask_confirm"))
+		msg.ReplyMarkup = matchConfirmKeyboard(r.lang(telegramID))
+		r.bot.Send(msg)
+		return
+	}
+	rows := slotRows(conflict.FreeSlots, func(slot time.Time) string {
+		return fmt.Sprintf("slot-%d", slot.Unix())
+	})
+	if conflict.Blocking() {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "create.cancel"), "отменить"),
+		))
+	} else {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "create.organize_anyway"), "организовать"),
+			tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "create.cancel"), "отменить"),
+		))
+	}
+	msg := tgbotapi.NewMessage(telegramID, conflictText(r.lang(telegramID), conflict))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	r.bot.Send(msg)
+}
+
+// moveNewMatch handles a "slot-<unix>" button offered instead of a busy time.
+func (r *router) moveNewMatch(telegramID int64, data string) {
+	unix, err := strconv.ParseInt(strings.TrimPrefix(data, "slot-"), 10, 64)
+	if err != nil {
+		return
+	}
+	r.cache.Reschedule(telegramID, time.Unix(unix, 0))
+	r.askConfirmMatch(telegramID)
+}
+
+// offerReschedule shows the organizer the nearest free slots for the match.
+func (r *router) offerReschedule(telegramID, matchID int64, user *entity.User) {
+	match, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	slots, err := r.service.GetFreeSlots(context.Background(), match)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(slots) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "reschedule.no_slots")))
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "reschedule.ask", matchID))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(slotRows(slots, func(slot time.Time) string {
+		return fmt.Sprintf("reschedule_to-%d-%d", matchID, slot.Unix())
+	})...)
+	r.bot.Send(msg)
+}
+
+func (r *router) rescheduleMatch(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	unix, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	match, err := r.service.RescheduleMatch(context.Background(), matchID, time.Unix(unix, 0))
+	if errors.Type(err) == errors.Conflict {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "reschedule.busy")))
+		r.offerReschedule(telegramID, matchID, user)
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	match, err = r.service.GetMatchByMatchID(context.Background(), match.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "reschedule.done", match.ID, slotLabel(match.StartAt))))
+	for _, member := range match.Players() {
+		msg := tgbotapi.NewMessage(member.ChatID, i18n.T(view.Lang(member), "reschedule.done", match.ID, slotLabel(match.StartAt)))
+		msg.ReplyMarkup = matchMoreKeyboard(view.Lang(member), match.ID)
+		r.notifier.Notify(member, enum.NotificationMatch, msg)
+	}
+	r.reportUnreachable(telegramID, match.Players())
+}
+
+func (r *router) toggleVenuePolicy(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	venueID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	if _, ok := r.ownedVenue(venueID, user); !ok {
+		return
+	}
+	policy := enum.ConflictPolicy(callbacks[2])
+	if err := r.service.SetVenueConflictPolicy(context.Background(), venueID, policy); err != nil {
+		log.Println(err)
+		return
+	}
+	text := r.t(telegramID, "venue.policy_warn_set")
+	if policy == enum.ConflictPolicyBlock {
+		text = r.t(telegramID, "venue.policy_block_set")
+	}
+	r.bot.Send(tgbotapi.NewMessage(telegramID, text))
+}
+
+func conflictText(lang i18n.Lang, conflict *entity.VenueConflict) string {
+	out := i18n.T(lang, "conflict.warn") + "\n"
+	if conflict.Blocking() {
+		out = i18n.T(lang, "conflict.block") + "\n"
+	}
+	for _, m := range conflict.Matches {
+		out += i18n.T(lang, "conflict.match", m.ID, slotLabel(m.StartAt), m.FinishAt.Format("15:04")) + "\n"
+	}
+	if len(conflict.FreeSlots) != 0 {
+		out += "\n" + i18n.T(lang, "conflict.free_slots")
+	}
+	return out
+}
+
+func slotRows(slots []time.Time, data func(time.Time) string) [][]tgbotapi.InlineKeyboardButton {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, slot := range slots {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(slotLabel(slot), data(slot)))
+	}
+	if len(row) == 0 {
+		return nil
+	}
+	return [][]tgbotapi.InlineKeyboardButton{row}
+}
+
+func slotLabel(t time.Time) string {
+	return t.Format("02.01 15:04")
+}
+
+func venuePolicyButton(lang i18n.Lang, venue *entity.Venue) tgbotapi.InlineKeyboardButton {
+	if venue.ConflictPolicy == enum.ConflictPolicyBlock {
+		return tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "venue.policy_block"),
+			fmt.Sprintf("venue_policy-%d-%s", venue.ID, enum.ConflictPolicyWarn))
+	}
+	return tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "venue.policy_warn"),
+		fmt.Sprintf("venue_policy-%d-%s", venue.ID, enum.ConflictPolicyBlock))
+}
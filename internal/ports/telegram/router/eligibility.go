@@ -0,0 +1,216 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var (
+	minAgeOptions = []int64{16, 18, 30, 40}
+	maxAgeOptions = []int64{17, 25, 35, 45}
+)
+
+// askEligibility shows the organizer the rules of the match with a row of
+// options for each of them.
+func (r *router) askEligibility(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	lang := r.lang(telegramID)
+	rules := view.Rules(lang, &m.Eligibility)
+	if rules == "" {
+		rules = i18n.T(lang, "eligibility.none")
+	}
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "eligibility.ask", rules))
+	msg.ReplyMarkup = eligibilityKeyboard(lang, m)
+	r.bot.Send(msg)
+}
+
+func eligibilityKeyboard(lang i18n.Lang, m *entity.Match) tgbotapi.InlineKeyboardMarkup {
+	e := m.Eligibility
+	option := func(label, field string, value any, selected bool) tgbotapi.InlineKeyboardButton {
+		if selected {
+			label = "✓ " + label
+		}
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_eligibility-%d-%s-%v", m.ID, field, value))
+	}
+	is := func(bound *int64, value int64) bool {
+		return bound == nil && value == 0 || bound != nil && *bound == value
+	}
+	minLevel := []tgbotapi.InlineKeyboardButton{option(i18n.T(lang, "eligibility.any_level"), "min_level", 0, e.MinLevel == nil)}
+	maxLevel := []tgbotapi.InlineKeyboardButton{}
+	for i, level := range view.Levels {
+		if i > 0 {
+			minLevel = append(minLevel, option(i18n.T(lang, "eligibility.level_min", i18n.T(lang, level.Key)), "min_level", level.Rating, is(e.MinLevel, level.Rating)))
+		}
+		if i < len(view.Levels)-1 {
+			maxLevel = append(maxLevel, option(i18n.T(lang, "eligibility.level_max", i18n.T(lang, level.Key)), "max_level", level.Rating, is(e.MaxLevel, level.Rating)))
+		}
+	}
+	maxLevel = append(maxLevel, option(i18n.T(lang, "eligibility.any_level"), "max_level", 0, e.MaxLevel == nil))
+	gender := []tgbotapi.InlineKeyboardButton{
+		option(i18n.T(lang, "eligibility.any_gender"), "gender", "", e.Gender == enum.GenderAny),
+		option(i18n.T(lang, "gender.female"), "gender", enum.GenderFemale, e.Gender == enum.GenderFemale),
+		option(i18n.T(lang, "gender.male"), "gender", enum.GenderMale, e.Gender == enum.GenderMale),
+	}
+	minAge := []tgbotapi.InlineKeyboardButton{option(i18n.T(lang, "eligibility.any_age"), "min_age", 0, e.MinAge == nil)}
+	for _, age := range minAgeOptions {
+		minAge = append(minAge, option(i18n.T(lang, "eligibility.age_min", age), "min_age", age, is(e.MinAge, age)))
+	}
+	maxAge := []tgbotapi.InlineKeyboardButton{}
+	for _, age := range maxAgeOptions {
+		maxAge = append(maxAge, option(i18n.T(lang, "eligibility.age_max", age), "max_age", age, is(e.MaxAge, age)))
+	}
+	maxAge = append(maxAge, option(i18n.T(lang, "eligibility.any_age"), "max_age", 0, e.MaxAge == nil))
+	return tgbotapi.NewInlineKeyboardMarkup(minLevel, maxLevel, gender, minAge, maxAge)
+}
+
+// setEligibility applies a "set_eligibility-<matchID>-<field>-<value>"
+// button press, zero and the empty gender lifting the rule.
+func (r *router) setEligibility(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 4 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	value := callbacks[3]
+	var bound *int64
+	if n, _ := strconv.ParseInt(value, 10, 64); n > 0 {
+		bound = &n
+	}
+	e := m.Eligibility
+	switch callbacks[2] {
+	case "min_level":
+		e.MinLevel = bound
+	case "max_level":
+		e.MaxLevel = bound
+	case "gender":
+		e.Gender = enum.Gender(value)
+	case "min_age":
+		e.MinAge = bound
+	case "max_age":
+		e.MaxAge = bound
+	default:
+		return
+	}
+	err := r.service.SetEligibility(context.Background(), matchID, &e)
+	if errors.Type(err) == errors.Invalid {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "eligibility.invalid")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.askEligibility(telegramID, matchID, user)
+}
+
+// ineligible tells the player which rule of the match keeps them from
+// signing up, false when they meet all of them.
+func (r *router) ineligible(telegramID int64, m *entity.Match, user *entity.User) bool {
+	if !m.Eligibility.IsSet() {
+		return false
+	}
+	profile, err := r.service.GetProfile(context.Background(), user.ID, m.Type)
+	if err != nil {
+		log.Println(err)
+		return false
+	}
+	rule := m.Eligibility.Unmet(profile, time.Now())
+	if rule == "" {
+		return false
+	}
+	lang := r.lang(telegramID)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, i18n.T(lang, "eligibility.unmet_"+string(rule), view.Rules(lang, &m.Eligibility))))
+	return true
+}
+
+// refuseSignUp tells the player whether the eligibility rules or the
+// reliability threshold of the match keep them out.
+func (r *router) refuseSignUp(telegramID int64, m *entity.Match, user *entity.User) {
+	if !r.ineligible(telegramID, m, user) && m.MinReliability != nil {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "signup.unreliable", *m.MinReliability)))
+	}
+}
+
+// showProfile shows the attributes the eligibility rules are checked
+// against and lets the player change them.
+func (r *router) showProfile(telegramID int64, user *entity.User) {
+	profile, err := r.service.GetProfile(context.Background(), user.ID, "")
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := r.lang(telegramID)
+	gender, birthYear := i18n.T(lang, "profile.unknown"), i18n.T(lang, "profile.unknown")
+	if profile.Gender != enum.GenderAny {
+		gender = i18n.T(lang, "profile."+string(profile.Gender))
+	}
+	if profile.BirthYear != nil {
+		birthYear = strconv.FormatInt(*profile.BirthYear, 10)
+	}
+	option := func(g enum.Gender) tgbotapi.InlineKeyboardButton {
+		label := i18n.T(lang, "profile."+string(g))
+		if profile.Gender == g {
+			label = "✓ " + label
+		}
+		return tgbotapi.NewInlineKeyboardButtonData(label, "set_gender-"+string(g))
+	}
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "profile.show", gender, birthYear))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(option(enum.GenderFemale), option(enum.GenderMale)),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "profile.birth_year"), "birth_year")),
+	)
+	r.bot.Send(msg)
+}
+
+func (r *router) setGender(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 2 {
+		return
+	}
+	if err := r.service.SetGender(context.Background(), user.ID, enum.Gender(callbacks[1])); err != nil {
+		log.Println(err)
+		return
+	}
+	r.showProfile(telegramID, user)
+}
+
+func (r *router) askBirthYear(telegramID int64) {
+	r.userCache.SetStatus(telegramID, users.StatusBirthYear)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "profile.ask_birth_year")))
+}
+
+func (r *router) setBirthYear(msg *tgbotapi.Message, user *entity.User) {
+	year, err := strconv.ParseInt(strings.TrimSpace(msg.Text), 10, 64)
+	if err != nil {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "profile.invalid_birth_year")))
+		return
+	}
+	err = r.service.SetBirthYear(context.Background(), user.ID, year)
+	if errors.Type(err) == errors.Invalid {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "profile.invalid_birth_year")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	r.showProfile(msg.From.ID, user)
+}
@@ -0,0 +1,171 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var expenseCategories = []enum.ExpenseCategory{
+	enum.ExpenseCategoryRent,
+	enum.ExpenseCategoryBalls,
+	enum.ExpenseCategoryWater,
+	enum.ExpenseCategoryReferee,
+	enum.ExpenseCategoryOther,
+}
+
+func expenseLabel(lang i18n.Lang, category enum.ExpenseCategory) string {
+	return i18n.T(lang, "expense."+string(category))
+}
+
+// showExpenses shows the organizer the expenses entered so far and the
+// resulting share per player.
+func (r *router) showExpenses(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	split, err := r.service.GetCostSplit(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := r.lang(telegramID)
+	msg := tgbotapi.NewMessage(telegramID, expensesText(lang, split))
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, c := range expenseCategories {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(expenseLabel(lang, c), fmt.Sprintf("expense_add-%d-%s", matchID, c)))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) != 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "expenses.send_split"), fmt.Sprintf("expenses_split-%d", matchID)),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "expenses.clear"), fmt.Sprintf("expenses_clear-%d", matchID)),
+	))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	r.bot.Send(msg)
+}
+
+func (r *router) askExpenseAmount(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	category := enum.ExpenseCategory(callbacks[2])
+	r.userCache.SetMatchID(telegramID, matchID)
+	r.userCache.SetExpenseCategory(telegramID, category)
+	r.userCache.SetStatus(telegramID, users.StatusExpenseAmount)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "expenses.ask_amount", expenseLabel(r.lang(telegramID), category))))
+}
+
+func (r *router) addExpense(msg *tgbotapi.Message, user *entity.User) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	amount, err := strconv.ParseInt(strings.TrimSpace(msg.Text), 10, 64)
+	if err == nil {
+		err = r.service.AddExpense(context.Background(), cached.MatchID, cached.ExpenseCategory, amount)
+	}
+	if errors.Type(err) == errors.Invalid || amount <= 0 {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "expenses.not_number")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	r.showExpenses(msg.From.ID, cached.MatchID, user)
+}
+
+func (r *router) clearExpenses(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	if err := r.service.ClearExpenses(context.Background(), matchID); err != nil {
+		log.Println(err)
+		return
+	}
+	r.showExpenses(telegramID, matchID, user)
+}
+
+// sendCostSplit messages every player their final share and balance.
+func (r *router) sendCostSplit(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	split, err := r.service.GetCostSplit(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(split.Balances) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "match.no_players")))
+		return
+	}
+	lang := r.lang(telegramID)
+	summary := expensesText(lang, split) + "\n"
+	for _, balance := range split.Balances {
+		memberLang := view.Lang(balance.User)
+		text := i18n.T(memberLang, "expenses.split",
+			matchID, split.Total, balance.Share, balance.Paid, balanceText(memberLang, balance.Balance))
+		msg := tgbotapi.NewMessage(balance.User.ChatID, text)
+		msg.ReplyMarkup = matchMoreKeyboard(memberLang, matchID)
+		r.notifier.Notify(balance.User, enum.NotificationPayment, msg)
+		summary += fmt.Sprintf("\n%s: %s", balance.User.DisplayName(), balanceText(lang, balance.Balance))
+	}
+	msg := tgbotapi.NewMessage(telegramID, summary)
+	msg.ReplyMarkup = matchMoreKeyboard(lang, matchID)
+	r.bot.Send(msg)
+}
+
+func expensesText(lang i18n.Lang, split *entity.CostSplit) string {
+	out := i18n.T(lang, "expenses.title", split.Match.ID) + "\n"
+	rentRecorded := false
+	for _, expense := range split.Expenses {
+		out += "\n" + expenseLabel(lang, expense.Category) + ": " + i18n.T(lang, "money", expense.Amount)
+		rentRecorded = rentRecorded || expense.Category == enum.ExpenseCategoryRent
+	}
+	if !rentRecorded {
+		out += "\n" + i18n.T(lang, "expenses.declared_rent", expenseLabel(lang, enum.ExpenseCategoryRent), split.Match.Rent)
+	}
+	out += "\n\n" + i18n.T(lang, "expenses.total", split.Total)
+	if len(split.Balances) != 0 {
+		out += "\n" + i18n.T(lang, "expenses.players", len(split.Balances))
+		for _, balance := range split.Balances {
+			out += "\n" + balance.User.DisplayName() + ": " + i18n.T(lang, "money", balance.Share)
+		}
+	}
+	return out
+}
+
+func balanceText(lang i18n.Lang, balance int64) string {
+	switch {
+	case balance > 0:
+		return i18n.T(lang, "balance.refund", balance)
+	case balance < 0:
+		return i18n.T(lang, "balance.owe", -balance)
+	default:
+		return i18n.T(lang, "balance.settled")
+	}
+}
@@ -0,0 +1,230 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// inviteLinkNameLimit is the longest name Telegram accepts for an invite
+// link.
+const inviteLinkNameLimit = 32
+
+// showMatchChat tells the organizer which group is linked to the match or
+// how to link one.
+func (r *router) showMatchChat(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	chat, err := r.service.GetMatchChat(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if chat == nil {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "group.how_to", matchID)))
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "group.linked", matchID, chat.Title))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "group.unlink"), fmt.Sprintf("unlink_chat-%d", matchID)),
+	))
+	r.bot.Send(msg)
+}
+
+// linkMatchChat handles "/link_chat <matchID>" sent by the organizer, who
+// must be an admin of the group, in a group where the bot can invite and
+// remove members, and invites the players already signed up.
+func (r *router) linkMatchChat(msg *tgbotapi.Message, user *entity.User) {
+	lang := r.lang(msg.From.ID)
+	reply := func(key string, args ...any) {
+		r.bot.Send(tgbotapi.NewMessage(msg.Chat.ID, i18n.T(lang, key, args...)))
+	}
+	if msg.Chat.IsPrivate() {
+		reply("group.only_in_group")
+		return
+	}
+	matchID, err := strconv.ParseInt(strings.TrimSpace(msg.CommandArguments()), 10, 64)
+	if err != nil {
+		reply("group.usage")
+		return
+	}
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		reply("group.not_organizer", matchID)
+		return
+	}
+	sender, err := r.bot.GetChatMember(tgbotapi.GetChatMemberConfig{ChatConfigWithUser: tgbotapi.ChatConfigWithUser{
+		ChatID: msg.Chat.ID,
+		UserID: msg.From.ID,
+	}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !sender.IsCreator() && !sender.IsAdministrator() {
+		reply("group.not_admin")
+		return
+	}
+	me, err := r.bot.GetChatMember(tgbotapi.GetChatMemberConfig{ChatConfigWithUser: tgbotapi.ChatConfigWithUser{
+		ChatID: msg.Chat.ID,
+		UserID: r.bot.Self.ID,
+	}})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !me.IsCreator() && !(me.IsAdministrator() && me.CanInviteUsers && me.CanRestrictMembers) {
+		reply("group.no_rights")
+		return
+	}
+	err = r.service.LinkMatchChat(context.Background(), &entity.MatchChat{MatchID: matchID, ChatID: msg.Chat.ID, Title: msg.Chat.Title})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	reply("group.linked_here", matchID)
+	for _, player := range m.Players() {
+		if player.ID != user.ID {
+			r.inviteToMatchChat(m, player)
+		}
+	}
+}
+
+func (r *router) unlinkMatchChat(telegramID, matchID int64, user *entity.User) {
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	chat, err := r.service.GetMatchChat(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if chat == nil {
+		return
+	}
+	invites, err := r.service.UnlinkMatchChat(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, invite := range invites {
+		r.revokeInviteLink(chat.ChatID, invite.InviteLink)
+	}
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "group.unlinked", matchID, chat.Title)))
+}
+
+// inviteToMatchChat sends the player a single-use link to the group of the
+// match, if the match has one. The link expires when the match is over.
+func (r *router) inviteToMatchChat(m *entity.Match, player *entity.User) {
+	chat, err := r.service.GetMatchChat(context.Background(), m.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if chat == nil {
+		return
+	}
+	invite, err := r.service.GetChatInvite(context.Background(), m.ID, player.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if invite == nil {
+		name := []rune(fmt.Sprintf("#%d %s", m.ID, player.DisplayName()))
+		if len(name) > inviteLinkNameLimit {
+			name = name[:inviteLinkNameLimit]
+		}
+		resp, err := r.bot.Request(tgbotapi.CreateChatInviteLinkConfig{
+			ChatConfig:  tgbotapi.ChatConfig{ChatID: chat.ChatID},
+			Name:        string(name),
+			ExpireDate:  int(m.FinishAt.Unix()),
+			MemberLimit: 1,
+		})
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		var link tgbotapi.ChatInviteLink
+		if err := json.Unmarshal(resp.Result, &link); err != nil {
+			log.Println(err)
+			return
+		}
+		invite = &entity.ChatInvite{MatchID: m.ID, UserID: player.ID, InviteLink: link.InviteLink}
+		if err := r.service.SaveChatInvite(context.Background(), invite); err != nil {
+			log.Println(err)
+			return
+		}
+	}
+	lang := view.Lang(player)
+	msg := tgbotapi.NewMessage(player.ChatID, i18n.T(lang, "group.invite", m.ID, chat.Title))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonURL(i18n.T(lang, "group.join"), invite.InviteLink),
+	))
+	r.notifier.Notify(player, enum.NotificationInvitation, msg)
+}
+
+// removeFromMatchChat revokes the link of the player who left the match
+// and removes them from its group, unless they organize the match, still
+// play in another upcoming match linked to the same group or were never
+// invited by the bot: members who joined the group on their own stay.
+func (r *router) removeFromMatchChat(m *entity.Match, player *entity.User) {
+	chat, err := r.service.GetMatchChat(context.Background(), m.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if chat == nil {
+		return
+	}
+	invite, err := r.service.GetChatInvite(context.Background(), m.ID, player.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if invite == nil {
+		return
+	}
+	r.revokeInviteLink(chat.ChatID, invite.InviteLink)
+	if err := r.service.DeleteChatInvite(context.Background(), m.ID, player.ID); err != nil {
+		log.Println(err)
+	}
+	if player.ID == m.OrganizerID {
+		return
+	}
+	keeps, err := r.service.KeepsMatchChat(context.Background(), chat, player.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if keeps {
+		return
+	}
+	// banning and right away unbanning removes the player while letting
+	// them join again with a new link
+	member := tgbotapi.ChatMemberConfig{ChatID: chat.ChatID, UserID: player.TelegramID}
+	if _, err := r.bot.Request(tgbotapi.BanChatMemberConfig{ChatMemberConfig: member, UntilDate: time.Now().Add(time.Minute).Unix()}); err != nil {
+		log.Println(err)
+		return
+	}
+	if _, err := r.bot.Request(tgbotapi.UnbanChatMemberConfig{ChatMemberConfig: member, OnlyIfBanned: true}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (r *router) revokeInviteLink(chatID int64, link string) {
+	_, err := r.bot.Request(tgbotapi.RevokeChatInviteLinkConfig{ChatConfig: tgbotapi.ChatConfig{ChatID: chatID}, InviteLink: link})
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// lang is the language of the user the bot is talking to, kept up to date
+// by syncUser on every update.
+func (r *router) lang(telegramID int64) i18n.Lang {
+	return r.userCache.GetLanguage(telegramID)
+}
+
+// t translates the message for the user the bot is talking to.
+func (r *router) t(telegramID int64, key string, args ...any) string {
+	return i18n.T(r.lang(telegramID), key, args...)
+}
+
+// tn translates the message that depends on the count n.
+func (r *router) tn(telegramID int64, key string, n int64, args ...any) string {
+	return i18n.N(r.lang(telegramID), key, n, args...)
+}
+
+func (r *router) askLanguage(telegramID int64) {
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, lang := range i18n.Langs {
+		label := lang.Name()
+		if r.lang(telegramID) == lang {
+			label = "✓ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_language-%s", lang)))
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "language.ask"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	r.bot.Send(msg)
+}
+
+func (r *router) setLanguage(telegramID int64, code string, user *entity.User) {
+	lang := i18n.Parse(code)
+	if err := r.service.SetLanguage(context.Background(), user.ID, lang); err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetLanguage(telegramID, lang)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "language.set")))
+}
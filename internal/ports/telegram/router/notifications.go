@@ -0,0 +1,143 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+)
+
+// quietHourOptions are the quiet hours offered in the settings, as
+// [from, to) pairs.
+var quietHourOptions = [][2]int64{{22, 8}, {23, 7}, {0, 9}}
+
+// utcOffsetOptions are the UTC offsets offered in the settings, the time
+// zones of the region the bot is used in.
+var utcOffsetOptions = []int64{3, 4, 5, 6}
+
+// showNotificationSettings lists the kinds of notifications with a toggle
+// for each of them, the delivery mode, the quiet hours and the time zone
+// they are in.
+func (r *router) showNotificationSettings(telegramID int64, user *entity.User) {
+	settings, err := r.service.GetNotificationSettings(context.Background(), user.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := r.lang(telegramID)
+	quiet := i18n.T(lang, "notify.quiet_off")
+	if settings.QuietFrom != nil && settings.QuietTo != nil {
+		quiet = i18n.T(lang, "notify.quiet_range", *settings.QuietFrom, *settings.QuietTo)
+	}
+	mode := i18n.T(lang, "notify.instant")
+	if settings.Digest {
+		mode = i18n.T(lang, "notify.digest_mode")
+	}
+	zone := i18n.T(lang, "notify.utc_offset", settings.UTCOffset)
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "notify.settings", mode, quiet, zone))
+	msg.ReplyMarkup = notificationKeyboard(lang, settings)
+	r.bot.Send(msg)
+}
+
+func notificationKeyboard(lang i18n.Lang, settings *entity.NotificationSettings) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, kind := range enum.NotificationKinds {
+		label := "🔕 " + i18n.T(lang, "notify.kind."+string(kind))
+		if settings.Enabled(kind) {
+			label = "🔔 " + i18n.T(lang, "notify.kind."+string(kind))
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, "notify_toggle-"+string(kind)))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = []tgbotapi.InlineKeyboardButton{}
+		}
+	}
+	if len(row) != 0 {
+		rows = append(rows, row)
+	}
+	mode := i18n.T(lang, "notify.switch_digest")
+	if settings.Digest {
+		mode = i18n.T(lang, "notify.switch_instant")
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(mode, "notify_digest")))
+	quiet := []tgbotapi.InlineKeyboardButton{}
+	for _, option := range quietHourOptions {
+		label := i18n.T(lang, "notify.quiet_range", option[0], option[1])
+		if settings.QuietFrom != nil && *settings.QuietFrom == option[0] && settings.QuietTo != nil && *settings.QuietTo == option[1] {
+			label = "✓ " + label
+		}
+		quiet = append(quiet, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("notify_quiet-%d-%d", option[0], option[1])))
+	}
+	off := i18n.T(lang, "notify.quiet_off")
+	if settings.QuietFrom == nil {
+		off = "✓ " + off
+	}
+	quiet = append(quiet, tgbotapi.NewInlineKeyboardButtonData(off, "notify_quiet-off"))
+	rows = append(rows, quiet)
+	zones := []tgbotapi.InlineKeyboardButton{}
+	for _, offset := range utcOffsetOptions {
+		label := i18n.T(lang, "notify.utc_offset", offset)
+		if settings.UTCOffset == offset {
+			label = "✓ " + label
+		}
+		zones = append(zones, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("notify_tz-%d", offset)))
+	}
+	rows = append(rows, zones)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// setNotificationSettings applies a "notify_toggle-<kind>", "notify_digest",
+// "notify_quiet-<from>-<to>|off" or "notify_tz-<offset>" button press.
+func (r *router) setNotificationSettings(telegramID int64, callbacks []string, user *entity.User) {
+	settings, err := r.service.GetNotificationSettings(context.Background(), user.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	switch callbacks[0] {
+	case "notify_toggle":
+		if len(callbacks) < 2 {
+			return
+		}
+		kind := callbacks[1]
+		if lo.Contains(settings.Muted, kind) {
+			settings.Muted = lo.Without(settings.Muted, kind)
+		} else {
+			settings.Muted = append(settings.Muted, kind)
+		}
+	case "notify_digest":
+		settings.Digest = !settings.Digest
+	case "notify_quiet":
+		if len(callbacks) == 2 && callbacks[1] == "off" {
+			settings.QuietFrom, settings.QuietTo = nil, nil
+			break
+		}
+		if len(callbacks) < 3 {
+			return
+		}
+		from, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		to, _ := strconv.ParseInt(callbacks[2], 10, 64)
+		settings.QuietFrom, settings.QuietTo = &from, &to
+	case "notify_tz":
+		if len(callbacks) < 2 {
+			return
+		}
+		offset, err := strconv.ParseInt(callbacks[1], 10, 64)
+		if err != nil {
+			return
+		}
+		settings.UTCOffset = offset
+	}
+	if err := r.service.SaveNotificationSettings(context.Background(), settings); err != nil {
+		log.Println(err)
+		return
+	}
+	r.showNotificationSettings(telegramID, user)
+}
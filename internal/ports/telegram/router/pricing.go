@@ -0,0 +1,160 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var pricingModels = []enum.PricingModel{
+	enum.PricingModelCapacity,
+	enum.PricingModelSignedUp,
+	enum.PricingModelAttended,
+	enum.PricingModelFixed,
+	enum.PricingModelFree,
+}
+
+var discountPercents = []int64{0, 50, 100}
+
+func (r *router) askPricing(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	lang := r.lang(telegramID)
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, model := range pricingModels {
+		label := i18n.T(lang, "pricing."+string(model))
+		if m.PricingModel == model {
+			label = "✓ " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_pricing-%d-%s", matchID, model)),
+		))
+	}
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "pricing.ask", m.BasePrice()))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	r.bot.Send(msg)
+}
+
+func (r *router) setPricing(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	model := enum.PricingModel(callbacks[2])
+	if model == enum.PricingModelFixed {
+		r.userCache.SetMatchID(telegramID, matchID)
+		r.userCache.SetStatus(telegramID, users.StatusFixedPrice)
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "pricing.ask_fixed")))
+		return
+	}
+	if err := r.service.SetPricingModel(context.Background(), matchID, model); err != nil {
+		log.Println(err)
+		return
+	}
+	r.sendPrice(telegramID, matchID)
+}
+
+func (r *router) setFixedPrice(msg *tgbotapi.Message) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	price, err := strconv.ParseInt(strings.TrimSpace(msg.Text), 10, 64)
+	if err == nil {
+		err = r.service.SetFixedPrice(context.Background(), cached.MatchID, price)
+	}
+	if errors.Type(err) == errors.Invalid || price < 0 {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "pricing.not_number")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	r.sendPrice(msg.From.ID, cached.MatchID)
+}
+
+func (r *router) sendPrice(telegramID, matchID int64) {
+	m, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := r.lang(telegramID)
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "pricing.updated", view.Price(lang, m)))
+	msg.ReplyMarkup = matchMoreKeyboard(lang, matchID)
+	r.bot.Send(msg)
+}
+
+// showDiscounts lists the players with their prices so the organizer can
+// give discounts or let someone play for free.
+func (r *router) showDiscounts(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	lang := r.lang(telegramID)
+	text := i18n.T(lang, "discount.title") + "\n"
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, team := range m.Teams {
+		for _, member := range team.Members {
+			text += fmt.Sprintf("\n%d. %s — %s", len(rows)+1, member.DisplayName(), i18n.T(lang, "money", m.PriceFor(member.ID)))
+			row := []tgbotapi.InlineKeyboardButton{}
+			for _, percent := range discountPercents {
+				label := fmt.Sprintf("%d: -%d%%", len(rows)+1, percent)
+				switch percent {
+				case 0:
+					label = fmt.Sprintf("%d: %s", len(rows)+1, i18n.T(lang, "discount.none"))
+				case 100:
+					label = fmt.Sprintf("%d: %s", len(rows)+1, i18n.T(lang, "discount.free"))
+				}
+				if m.Discount(member.ID) == percent {
+					label = "✓ " + label
+				}
+				row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("set_discount-%d-%d-%d", matchID, member.ID, percent)))
+			}
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "match.no_players")))
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	r.bot.Send(msg)
+}
+
+func (r *router) setDiscount(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 4 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	percent, _ := strconv.ParseInt(callbacks[3], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	if err := r.service.SetDiscount(context.Background(), matchID, memberID, percent); err != nil {
+		log.Println(err)
+		return
+	}
+	r.showDiscounts(telegramID, matchID, user)
+}
@@ -0,0 +1,45 @@
+package router
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+)
+
+// reportUnreachable tells the user who just messaged the players which of
+// them the bot can't write to, so that they reach them some other way.
+func (r *router) reportUnreachable(telegramID int64, players []*entity.User) {
+	ids := lo.FilterMap(players, func(p *entity.User, _ int) (int64, bool) { return p.ID, p.TelegramID != telegramID })
+	unreachable, err := r.service.GetUnreachableUsers(context.Background(), ids)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(unreachable) == 0 {
+		return
+	}
+	lang := r.lang(telegramID)
+	lines := make([]string, 0, len(unreachable))
+	for _, player := range unreachable {
+		lines = append(lines, i18n.T(lang, "reach.player", player.DisplayName(), i18n.T(lang, "reach.reason."+string(player.Unreachable))))
+	}
+	r.bot.Send(tgbotapi.NewMessage(telegramID, i18n.T(lang, "reach.unreachable", strings.Join(lines, "\n"))))
+}
+
+// chatMemberUpdated flags the user who blocked the bot right away, rather
+// than on the next message that fails. Unblocking it is an update from the
+// user like any other and has already made them reachable.
+func (r *router) chatMemberUpdated(update *tgbotapi.ChatMemberUpdated) {
+	if update.Chat.Type != "private" || update.NewChatMember.Status != "kicked" {
+		return
+	}
+	if err := r.service.MarkUnreachable(context.Background(), update.Chat.ID, enum.UnreachableBlocked); err != nil {
+		log.Println(err)
+	}
+}
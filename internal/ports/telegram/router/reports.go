@@ -0,0 +1,178 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/outbox"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// sendReport adds a message of the organizer to the report being composed.
+// Albums arrive as one message per file, so only the first one is answered.
+func (r *router) sendReport(msg *tgbotapi.Message, user *entity.User) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	text := msg.Text
+	if text == "" {
+		text = msg.Caption
+	}
+	attachments := messageAttachments(msg)
+	if text == "" && len(attachments) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "report.ask_content")))
+		return
+	}
+	report, err := r.service.AddToReport(context.Background(), cached.MatchID, user.ID, text, attachments)
+	if errors.Type(err) == errors.Forbidden {
+		r.userCache.SetStatus(msg.From.ID, 0)
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !r.userCache.FirstOfMediaGroup(msg.From.ID, msg.MediaGroupID) {
+		return
+	}
+	reply := tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "report.added"))
+	reply.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(r.t(msg.From.ID, "report.publish"), fmt.Sprintf("report_publish-%d", report.ID)),
+		),
+	)
+	r.bot.Send(reply)
+}
+
+// publishReport sends the finished report to every player of the match.
+func (r *router) publishReport(telegramID, reportID int64, user *entity.User) {
+	report, err := r.service.PublishReport(context.Background(), reportID, user.ID)
+	switch errors.Type(err) {
+	case errors.Conflict:
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "report.already_published")))
+		return
+	case errors.Invalid:
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "report.empty")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(telegramID, 0)
+	match, err := r.service.GetMatchByMatchID(context.Background(), report.MatchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, member := range match.Players() {
+		chatID := member.ChatID
+		// a held report reaches the player without its attachments, they
+		// are one tap away in the reports of the match
+		if r.notifier.Notify(member, enum.NotificationReport, reportMessage(view.Lang(member), chatID, report)) {
+			for _, album := range albums(report.Attachments) {
+				r.bot.DeliverAlbum(enum.NotificationReport, chatID, album)
+			}
+		}
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "report.published"))
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), match.ID)
+	r.bot.Send(msg)
+	r.reportUnreachable(telegramID, match.Players())
+}
+
+// showReports sends the latest reports of the match, oldest first.
+func (r *router) showReports(telegramID, matchID int64) {
+	reports, err := r.service.GetMatchReports(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(reports) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "report.none")))
+		return
+	}
+	for i := len(reports) - 1; i >= 0; i-- {
+		r.sendMatchReport(r.lang(telegramID), telegramID, reports[i])
+	}
+}
+
+func (r *router) sendMatchReport(lang i18n.Lang, chatID int64, report *entity.Report) {
+	r.bot.Send(reportMessage(lang, chatID, report))
+	r.sendAttachments(chatID, report.Attachments)
+}
+
+func reportMessage(lang i18n.Lang, chatID int64, report *entity.Report) tgbotapi.MessageConfig {
+	text := i18n.T(lang, "report.title", report.MatchID, report.CreatedAt.Format("02.01 15:04"))
+	if report.Text != "" {
+		text += "\n\n" + report.Text
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = matchMoreKeyboard(lang, report.MatchID)
+	return msg
+}
+
+// sendAttachments sends the attachments of the report right away.
+func (r *router) sendAttachments(chatID int64, attachments []*entity.Attachment) {
+	for _, album := range albums(attachments) {
+		request := outbox.Album(chatID, album)
+		if group, ok := request.(tgbotapi.MediaGroupConfig); ok {
+			if _, err := r.bot.SendMediaGroup(group); err != nil {
+				log.Println(err)
+			}
+		} else if request != nil {
+			r.bot.Send(request)
+		}
+	}
+}
+
+// albums splits the attachments into the albums they are sent in: photos
+// and videos together and documents apart, since Telegram does not mix
+// them, at most outbox.AlbumSize in each.
+func albums(attachments []*entity.Attachment) [][]*entity.Attachment {
+	var media, documents []*entity.Attachment
+	for _, attachment := range attachments {
+		if attachment.Kind == enum.AttachmentKindDocument {
+			documents = append(documents, attachment)
+		} else {
+			media = append(media, attachment)
+		}
+	}
+	var albums [][]*entity.Attachment
+	for _, group := range [][]*entity.Attachment{media, documents} {
+		for start := 0; start < len(group); start += outbox.AlbumSize {
+			end := start + outbox.AlbumSize
+			if end > len(group) {
+				end = len(group)
+			}
+			albums = append(albums, group[start:end])
+		}
+	}
+	return albums
+}
+
+func messageAttachments(msg *tgbotapi.Message) []*entity.Attachment {
+	var attachments []*entity.Attachment
+	if len(msg.Photo) != 0 {
+		// the last size is the largest one
+		attachments = append(attachments, &entity.Attachment{
+			Kind:   enum.AttachmentKindPhoto,
+			FileID: msg.Photo[len(msg.Photo)-1].FileID,
+		})
+	}
+	if msg.Video != nil {
+		attachments = append(attachments, &entity.Attachment{Kind: enum.AttachmentKindVideo, FileID: msg.Video.FileID})
+	}
+	if msg.Document != nil {
+		attachments = append(attachments, &entity.Attachment{Kind: enum.AttachmentKindDocument, FileID: msg.Document.FileID})
+	}
+	return attachments
+}
@@ -0,0 +1,171 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// RequestResults asks the organizers of the matches that have just finished
+// to record the score.
+func (r *router) RequestResults() {
+	matches, err := r.service.TakeFinishedMatches(context.Background())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, m := range matches {
+		organizer, err := r.service.GetUserByID(context.Background(), m.OrganizerID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		msg := tgbotapi.NewMessage(organizer.ChatID, i18n.T(view.Lang(organizer), "result.request", m.ID))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(i18n.T(view.Lang(organizer), "result.record"), fmt.Sprintf("result-%d", m.ID)),
+			),
+		)
+		r.notifier.Notify(organizer, enum.NotificationMatch, msg)
+	}
+}
+
+func (r *router) askScore(telegramID, matchID int64, user *entity.User) {
+	m, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	if time.Now().Before(m.FinishAt) {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "result.not_finished")))
+		return
+	}
+	r.userCache.SetMatchID(telegramID, matchID)
+	r.userCache.SetStatus(telegramID, users.StatusMatchScore)
+	teams := make([]string, 0, len(m.Teams))
+	for _, team := range m.Teams {
+		teams = append(teams, view.TeamLabel(team))
+	}
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "result.ask", strings.Join(teams, " "))))
+}
+
+// setScore saves the score typed by the organizer, shares it with the
+// players and offers to mark the scorers and the MVP.
+func (r *router) setScore(msg *tgbotapi.Message) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	var scores []int64
+	for _, field := range strings.Fields(strings.ReplaceAll(msg.Text, ":", " ")) {
+		score, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "result.not_numbers")))
+			return
+		}
+		scores = append(scores, score)
+	}
+	m, err := r.service.RecordScore(context.Background(), cached.MatchID, scores)
+	if errors.Type(err) == errors.Invalid {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "result.each_team")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	for _, member := range m.Players() {
+		notice := tgbotapi.NewMessage(member.ChatID, i18n.T(view.Lang(member), "result.notice", m.ID, view.Score(m)))
+		notice.ReplyMarkup = matchMoreKeyboard(view.Lang(member), m.ID)
+		r.notifier.Notify(member, enum.NotificationMatch, notice)
+	}
+	r.reportUnreachable(msg.From.ID, m.Players())
+	r.showScorers(msg.From.ID, m)
+}
+
+// showScorers lists the players with buttons to count their goals and to
+// choose the MVP.
+func (r *router) showScorers(telegramID int64, m *entity.Match) {
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "result.ask_scorers")))
+	for _, team := range m.Teams {
+		for _, member := range team.Members {
+			msg := tgbotapi.NewMessage(telegramID, fmt.Sprintf("%s %s", view.TeamLabel(team), member.DisplayName()))
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+				tgbotapi.NewInlineKeyboardRow(
+					tgbotapi.NewInlineKeyboardButtonData("⚽️ +1", fmt.Sprintf("goal-%d-%d-add", m.ID, member.ID)),
+					tgbotapi.NewInlineKeyboardButtonData("⚽️ −1", fmt.Sprintf("goal-%d-%d-sub", m.ID, member.ID)),
+					tgbotapi.NewInlineKeyboardButtonData("⭐️ MVP", fmt.Sprintf("mvp-%d-%d", m.ID, member.ID)),
+				),
+			)
+			r.bot.Send(msg)
+		}
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "result.done_hint"))
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), m.ID)
+	r.bot.Send(msg)
+}
+
+func (r *router) addGoal(callback *tgbotapi.CallbackQuery, callbacks []string, user *entity.User) {
+	if len(callbacks) < 4 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	delta, text := int64(1), r.t(callback.From.ID, "result.goal_added")
+	if callbacks[3] == "sub" {
+		delta, text = -1, r.t(callback.From.ID, "result.goal_removed")
+	}
+	if err := r.service.AddPlayerGoals(context.Background(), matchID, memberID, delta); err != nil {
+		log.Println(err)
+		return
+	}
+	r.bot.Send(tgbotapi.NewCallback(callback.ID, text))
+}
+
+func (r *router) setMVP(callback *tgbotapi.CallbackQuery, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	if err := r.service.SetMatchMVP(context.Background(), matchID, memberID); err != nil {
+		log.Println(err)
+		return
+	}
+	r.bot.Send(tgbotapi.NewCallback(callback.ID, r.t(callback.From.ID, "result.mvp_set")))
+}
+
+func (r *router) showStats(chatID int64, user *entity.User) {
+	stats, err := r.service.GetPlayerStats(context.Background(), user.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(stats) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(chatID, r.t(chatID, "stats.none")))
+		return
+	}
+	out := r.t(chatID, "stats.title") + "\n"
+	for _, s := range stats {
+		out += r.t(chatID, "stats.sport", r.sportName(r.lang(chatID), s.Sport), s.Played, s.Wins, s.Draws, s.Losses, s.Goals, s.MVP)
+	}
+	r.bot.Send(tgbotapi.NewMessage(chatID, out))
+}
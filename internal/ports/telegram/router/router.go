@@ -8,32 +8,44 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf16"
 
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/matches"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/notifier"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/outbox"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/service/match"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
 )
 
 type Router interface {
 	HandleUpdate(update tgbotapi.Update)
+	RequestResults()
 }
 
 type router struct {
-	bot       *tgbotapi.BotAPI
+	bot       *outbox.Bot
 	cache     matches.Cache
 	userCache users.Cache
 	service   match.Service
+	notifier  notifier.Notifier
+	adminIDs  []int64
 }
 
-func NewRouter(bot *tgbotapi.BotAPI, cache matches.Cache, userCache users.Cache, service match.Service) Router {
+func NewRouter(bot *outbox.Bot, cache matches.Cache, userCache users.Cache, service match.Service, notifier notifier.Notifier, adminIDs []int64) Router {
 	return &router{
 		bot:       bot,
 		cache:     cache,
 		service:   service,
 		userCache: userCache,
+		notifier:  notifier,
+		adminIDs:  adminIDs,
 	}
 }
 
@@ -43,25 +55,50 @@ func (r *router) HandleUpdate(update tgbotapi.Update) {
 			log.Printf("recovered from panic: %v\n%v", panicValue, string(debug.Stack()))
 		}
 	}()
+	from := update.SentFrom()
+	if from == nil && update.MyChatMember != nil {
+		from = &update.MyChatMember.From
+	}
+	if from == nil {
+		return
+	}
+	user, invitations, err := r.syncUser(from)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.claimInvitations(user, invitations)
 	switch {
+	case update.MyChatMember != nil:
+		r.chatMemberUpdated(update.MyChatMember)
 	case update.CallbackQuery != nil:
-		r.handleCallback(update.CallbackQuery)
+		r.handleCallback(update.CallbackQuery, user)
 	case update.Message != nil:
-		r.handleMessage(update.Message)
-	default:
-		user := entity.User{
-			Name:     update.MyChatMember.From.FirstName,
-			Username: update.MyChatMember.From.UserName,
-			ChatID:   int(update.MyChatMember.From.ID),
-		}
-		r.service.CreateUser(context.Background(), &user)
+		r.handleMessage(update.Message, user)
+	}
+}
+
+// syncUser stores the sender of an update, keeping the username, name and
+// language up to date since Telegram lets users change them at any time.
+func (r *router) syncUser(from *tgbotapi.User) (*entity.User, []*entity.Invitation, error) {
+	user, invitations, err := r.service.SyncUser(context.Background(), &entity.User{
+		TelegramID: from.ID,
+		Name:       from.FirstName,
+		Username:   from.UserName,
+		ChatID:     from.ID,
+		Language:   from.LanguageCode,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	r.userCache.SetLanguage(from.ID, view.Lang(user))
+	return user, invitations, nil
 }
 
-func (r *router) handleCallback(callback *tgbotapi.CallbackQuery) {
-	status, err := r.cache.GetStatus(callback.From.UserName)
+func (r *router) handleCallback(callback *tgbotapi.CallbackQuery, user *entity.User) {
+	status, err := r.cache.GetStatus(callback.From.ID)
 	if err == nil {
-		r.createMatchCallback(callback, status)
+		r.createMatchCallback(callback, user, status)
 		return
 	}
 	callbacks := strings.Split(callback.Data, "-")
@@ -70,13 +107,16 @@ func (r *router) handleCallback(callback *tgbotapi.CallbackQuery) {
 	}
 	switch callbacks[0] {
 	case "send_report":
-		matchID, _ := strconv.Atoi(callbacks[1])
-		r.userCache.SetMatchID(callback.From.UserName, int64(matchID))
-		r.userCache.SetStatus(callback.From.UserName, users.StatusSendReport)
-		msg := tgbotapi.NewMessage(callback.From.ID, `Отправьте отчет о расходах или о матче`)
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		if _, ok := r.organizedMatch(matchID, user); !ok {
+			return
+		}
+		r.userCache.SetMatchID(callback.From.ID, matchID)
+		r.userCache.SetStatus(callback.From.ID, users.StatusSendReport)
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "report.ask"))
 		r.bot.Send(msg)
 	case "add_members":
-		msg := tgbotapi.NewMessage(callback.From.ID, "Добавить в команду:")
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "members.choose_team"))
 		id, _ := strconv.Atoi(callbacks[1])
 		match, err := r.service.GetMatchByMatchID(context.Background(), int64(id))
 		if err != nil {
@@ -93,42 +133,89 @@ func (r *router) handleCallback(callback *tgbotapi.CallbackQuery) {
 			return
 		}
 		r.service.CancelMatch(context.Background(), int64(matchID))
-		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, "Вы отменили матч"))
-		for _, team := range match.Teams {
-			for _, member := range team.Members {
-				r.bot.Send(tgbotapi.NewMessage(int64(member.ChatID), fmt.Sprintf("Матч #%d отменен", matchID)))
-				r.bot.Send(tgbotapi.NewMessage(int64(member.ChatID), fmt.Sprintf("Ваш взнос за матч  #%d был возращен", matchID)))
-			}
+		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "match.you_cancelled")))
+		for _, member := range match.Players() {
+			lang := view.Lang(member)
+			text := i18n.T(lang, "match.cancelled", matchID) + "\n" + i18n.T(lang, "match.fee_refunded", matchID)
+			r.notifier.Notify(member, enum.NotificationMatch, tgbotapi.NewMessage(member.ChatID, text))
 		}
+		r.reportUnreachable(callback.From.ID, match.Players())
 
 	case "add_team_members":
 		teamID, _ := strconv.Atoi(callbacks[1])
-		r.userCache.SetTeamID(callback.From.UserName, int64(teamID))
-		r.userCache.SetStatus(callback.From.UserName, users.StatusAddTeamMembers)
-		msg := tgbotapi.NewMessage(callback.From.ID, `Отправьте юзернеймы тех, кого хотите добавить, 
-		через пробел и с "@" в начале`)
+		r.userCache.SetTeamID(callback.From.ID, int64(teamID))
+		r.userCache.SetStatus(callback.From.ID, users.StatusAddTeamMembers)
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "members.ask"))
 		r.bot.Send(msg)
 	case "get_matches_by_sport":
-		matches, err := r.service.GetOpenMatchesBySport(context.Background(), enum.SportType(callbacks[1]))
-		if err != nil {
-			log.Println(err)
-			return
-		}
-		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, fmt.Sprintf(`🔜 Ближайшие матчи по %sу
-
-		`, callbacks[1])))
-		if len(matches) == 0 {
-			r.bot.Send(tgbotapi.NewMessage(callback.From.ID, `😥 К сожалению, матчей нет`))
-			return
-		}
-		for _, m := range matches {
-			msg := tgbotapi.NewMessage(callback.From.ID,
-				fmt.Sprintf(`Матч #%d - Начало %d/%d %d:00(%.1f часа) - %d тг/чел - Осталось %d мест`,
-					m.ID, m.StartAt.Day(), m.StartAt.Month(), m.StartAt.Hour(), float64(m.FinishAt.Sub(m.StartAt).Minutes())/60.0,
-					m.Rent/(m.TeamCount*m.TeamSize), (m.TeamCount*m.TeamSize)-m.MembersCount))
-			msg.ReplyMarkup = matchMoreKeyboard(m.ID)
-			r.bot.Send(msg)
-		}
+		r.startSearch(callback.From.ID, enum.SportType(callbacks[1]))
+	case "filter":
+		r.handleFilterCallback(callback, callbacks, user)
+	case "venue_location":
+		venueID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askVenueLocation(callback.From.ID, venueID, user)
+	case "ratings":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showRatings(callback.From.ID, matchID, user)
+	case "set_rating":
+		r.setRating(callback, callbacks, user)
+	case "balance":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.previewBalance(callback.From.ID, matchID, user)
+	case "balance_apply":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.applyBalance(callback.From.ID, matchID, user)
+	case "result":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askScore(callback.From.ID, matchID, user)
+	case "goal":
+		r.addGoal(callback, callbacks, user)
+	case "mvp":
+		r.setMVP(callback, callbacks, user)
+	case "roster":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showRoster(callback.From.ID, matchID, user)
+	case "attend":
+		r.setAttendance(callback, callbacks, user)
+	case "min_reliability":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askMinReliability(callback.From.ID, matchID, user)
+	case "eligibility":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askEligibility(callback.From.ID, matchID, user)
+	case "set_eligibility":
+		r.setEligibility(callback.From.ID, callbacks, user)
+	case "chat":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.openChat(callback.From.ID, matchID, user)
+	case "match_chat":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showMatchChat(callback.From.ID, matchID, user)
+	case "unlink_chat":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.unlinkMatchChat(callback.From.ID, matchID, user)
+	case "chat_leave":
+		r.leaveChat(callback.From.ID)
+	case "chat_mute":
+		r.muteChat(callback.From.ID, callbacks, user)
+	case "set_gender":
+		r.setGender(callback.From.ID, callbacks, user)
+	case "birth_year":
+		r.askBirthYear(callback.From.ID)
+	case "notify_toggle", "notify_digest", "notify_quiet", "notify_tz":
+		r.setNotificationSettings(callback.From.ID, callbacks, user)
+	case "set_min_reliability":
+		r.setMinReliability(callback.From.ID, callbacks, user)
+	case "venue_policy":
+		r.toggleVenuePolicy(callback.From.ID, callbacks, user)
+	case "reschedule":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.offerReschedule(callback.From.ID, matchID, user)
+	case "reschedule_to":
+		r.rescheduleMatch(callback.From.ID, callbacks, user)
+	case "venue_photo":
+		venueID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askVenuePhoto(callback.From.ID, venueID, user)
 	case "get_match_by_id":
 		matchID, _ := strconv.Atoi(callbacks[1])
 		match, err := r.service.GetMatchByMatchID(context.Background(), int64(matchID))
@@ -136,33 +223,54 @@ func (r *router) handleCallback(callback *tgbotapi.CallbackQuery) {
 			log.Println(err)
 			return
 		}
-		msg := tgbotapi.NewMessage(callback.From.ID,
-			fmt.Sprint(match),
-		)
+		r.sendVenue(callback.From.ID, match.Venue)
+		lang := r.lang(callback.From.ID)
+		msg := tgbotapi.NewMessage(callback.From.ID, view.Card(lang, match, user.ID))
+		msg.ParseMode = tgbotapi.ModeHTML
+		button := func(key, data string) tgbotapi.InlineKeyboardButton {
+			return tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, key), fmt.Sprintf("%s-%d", data, matchID))
+		}
 		rows := [][]tgbotapi.InlineKeyboardButton{}
-		if match.OrganizerUsername == callback.From.UserName {
-			rows = append(rows, []tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardButtonData("Отменить матч", fmt.Sprintf("cancel_match-%d", matchID)),
-				tgbotapi.NewInlineKeyboardButtonData("Отправить отчет", fmt.Sprintf("send_report-%d", matchID)),
+		if match.OrganizerID == user.ID {
+			rows = append(rows, []tgbotapi.InlineKeyboardButton{button("button.cancel_match", "cancel_match"),
+				button("button.send_report", "send_report"),
+				button("button.reschedule", "reschedule"),
+				button("button.expenses", "expenses"),
+			}, []tgbotapi.InlineKeyboardButton{
+				button("button.ratings", "ratings"),
+				button("button.balance", "balance"),
+				button("button.move_members", "move_members"),
+				button("button.teams", "teams_edit"),
+			}, []tgbotapi.InlineKeyboardButton{
+				button("button.roster", "roster"),
+				button("button.min_reliability", "min_reliability"),
+				button("button.cancel_window", "cancel_window"),
+				button("button.eligibility", "eligibility"),
+			}, []tgbotapi.InlineKeyboardButton{
+				button("button.pricing", "pricing"),
+				button("button.discounts", "discounts"),
+				button("button.group", "match_chat"),
 			})
+			if time.Now().After(match.FinishAt) {
+				rows = append(rows, []tgbotapi.InlineKeyboardButton{
+					button("button.result", "result"),
+				})
+			}
 		}
-		nextRows := []tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardButtonData(
-			"Записаться на матч",
-			fmt.Sprintf("signup_match-%d", matchID))}
+		nextRows := []tgbotapi.InlineKeyboardButton{button("button.signup", "signup_match")}
 		for _, team := range match.Teams {
 			for _, member := range team.Members {
-				if callback.From.UserName == member.Username {
+				if user.ID == member.ID {
 					nextRows = append(nextRows[:len(nextRows)-1],
-						tgbotapi.NewInlineKeyboardButtonData("Отменить участие", fmt.Sprintf("signout_match-%d", matchID)),
+						button("button.signout", "signout_match"),
+						button("button.transfer", "transfer"),
+						button("button.switch_team", "switch_team"),
 					)
 					if !member.Confirmed {
-						nextRows = append(nextRows, tgbotapi.NewInlineKeyboardButtonData(
-							"Подтвердить участие", fmt.Sprintf("confirm_match-%d", matchID),
-						))
+						nextRows = append(nextRows, button("button.confirm", "confirm_match"))
 					}
 					if !member.Paid {
-						nextRows = append(nextRows, tgbotapi.NewInlineKeyboardButtonData(
-							"Оплатить взнос", fmt.Sprintf("pay_match-%d", matchID),
-						))
+						nextRows = append(nextRows, button("button.pay", "pay_match"))
 					}
 					break
 				}
@@ -170,65 +278,117 @@ func (r *router) handleCallback(callback *tgbotapi.CallbackQuery) {
 			}
 
 		}
-		rows = append(rows, nextRows)
+		infoRow := []tgbotapi.InlineKeyboardButton{button("button.reports", "reports")}
+		if match.OrganizerID == user.ID || match.TeamOf(user.ID) != nil {
+			infoRow = append(infoRow, button("button.chat", "chat"))
+		}
+		rows = append(rows, infoRow, nextRows)
 		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
 		r.bot.Send(msg)
 	case "pay_match":
 		matchID, _ := strconv.Atoi(callbacks[1])
 		match, _ := r.service.GetMatchByMatchID(context.Background(), int64(matchID))
-		organizer, _ := r.service.GetUserByUsername(context.Background(), match.OrganizerUsername)
-		user, _ := r.service.GetUserByUsername(context.Background(), callback.From.UserName)
+		organizer, _ := r.service.GetUserByID(context.Background(), match.OrganizerID)
 		r.service.SetMatchPaid(context.Background(), true, user.ID, match.ID)
-		msg := tgbotapi.NewMessage(callback.From.ID, "Вы оплатили взнос")
-		msg.ReplyMarkup = matchMoreKeyboard(int64(matchID))
-		r.bot.Send(msg)
-		msg = tgbotapi.NewMessage(int64(organizer.ChatID), fmt.Sprintf("@%s оплатил взнос в матче %d", user.Username, matchID))
-		msg.ReplyMarkup = matchMoreKeyboard(match.ID)
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "payment.done"))
+		msg.ReplyMarkup = matchMoreKeyboard(r.lang(callback.From.ID), int64(matchID))
 		r.bot.Send(msg)
+		msg = tgbotapi.NewMessage(organizer.ChatID, i18n.T(view.Lang(organizer), "payment.organizer", user.DisplayName(), matchID))
+		msg.ReplyMarkup = matchMoreKeyboard(view.Lang(organizer), match.ID)
+		r.notifier.Notify(organizer, enum.NotificationPayment, msg)
 	case "confirm_match":
 		matchID, _ := strconv.Atoi(callbacks[1])
 		match, _ := r.service.GetMatchByMatchID(context.TODO(), int64(matchID))
-		organizer, _ := r.service.GetUserByUsername(context.Background(), match.OrganizerUsername)
-		user, _ := r.service.GetUserByUsername(context.Background(), callback.From.UserName)
+		organizer, _ := r.service.GetUserByID(context.Background(), match.OrganizerID)
 		r.service.SetMatchConfirmed(context.Background(), true, user.ID, int64(matchID))
-		msg := tgbotapi.NewMessage(callback.From.ID, "Вы подтвердили участие в матче")
-		msg.ReplyMarkup = matchMoreKeyboard(int64(matchID))
-		r.bot.Send(msg)
-		msg = tgbotapi.NewMessage(int64(organizer.ChatID), fmt.Sprintf("@%s подтвердил участие в матче %d", user.Username, matchID))
-		msg.ReplyMarkup = matchMoreKeyboard(match.ID)
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "confirm.done"))
+		msg.ReplyMarkup = matchMoreKeyboard(r.lang(callback.From.ID), int64(matchID))
 		r.bot.Send(msg)
+		msg = tgbotapi.NewMessage(organizer.ChatID, i18n.T(view.Lang(organizer), "confirm.organizer", user.DisplayName(), matchID))
+		msg.ReplyMarkup = matchMoreKeyboard(view.Lang(organizer), match.ID)
+		r.notifier.Notify(organizer, enum.NotificationConfirm, msg)
 	case "signout_match":
-		matchID, _ := strconv.Atoi(callbacks[1])
-		match, _ := r.service.GetMatchByMatchID(context.TODO(), int64(matchID))
-		organizer, _ := r.service.GetUserByUsername(context.Background(), match.OrganizerUsername)
-		user, _ := r.service.GetUserByUsername(context.Background(), callback.From.UserName)
-		r.service.SignOutMatch(context.Background(), int64(matchID), user.ID)
-		msg := tgbotapi.NewMessage(callback.From.ID, "Вы отменили участие в матче")
-		msg.ReplyMarkup = matchMoreKeyboard(int64(matchID))
-		r.bot.Send(msg)
-		msg = tgbotapi.NewMessage(int64(organizer.ChatID), fmt.Sprintf("@%s отменил участие в матче %d", user.Username, matchID))
-		msg.ReplyMarkup = matchMoreKeyboard(match.ID)
-		r.bot.Send(msg)
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askSignOut(callback.From.ID, matchID, user)
+	case "signout_confirm":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.confirmSignOut(callback.From.ID, matchID, user)
+	case "signout_replace", "transfer":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askTransfer(callback.From.ID, matchID)
+	case "transfer_accept":
+		transferID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.acceptTransfer(callback, transferID, user)
+	case "transfer_decline":
+		transferID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.declineTransfer(callback, transferID, user)
+	case "cancel_window":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askCancelWindow(callback.From.ID, matchID, user)
+	case "set_cancel_window":
+		r.setCancelWindow(callback.From.ID, callbacks, user)
 	case "signup_match":
-		matchID, _ := strconv.Atoi(callbacks[1])
-		match, _ := r.service.GetMatchByMatchID(context.Background(), int64(matchID))
-		organizer, _ := r.service.GetUserByUsername(context.Background(), match.OrganizerUsername)
-		teamID := 0
-		min := match.TeamSize
-		for _, team := range match.Teams {
-			if len(team.Members) < int(min) {
-				teamID = int(team.ID)
-				min = int64(len(team.Members))
-			}
-		}
-		user, _ := r.service.GetUserByUsername(context.Background(), callback.From.UserName)
-		r.service.SignUpToMatch(context.Background(), user.ID, int64(teamID))
-		msg := tgbotapi.NewMessage(callback.From.ID, "Вы записались на матч")
-		msg.ReplyMarkup = matchMoreKeyboard(match.ID)
-		r.bot.Send(msg)
-		msg = tgbotapi.NewMessage(int64(organizer.ChatID), fmt.Sprintf("@%s записался на матч %d", user.Username, matchID))
-		msg.ReplyMarkup = matchMoreKeyboard(match.ID)
-		r.bot.Send(msg)
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askTeam(callback.From.ID, matchID)
+	case "signup_team":
+		r.signUp(callback.From.ID, callbacks, user)
+	case "switch_team":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askSwitchTeam(callback.From.ID, matchID, user)
+	case "switch_request":
+		r.requestSwitch(callback.From.ID, callbacks, user)
+	case "switch_reject":
+		r.rejectSwitch(callback.From.ID, callbacks, user)
+	case "move_members":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showMoves(callback.From.ID, matchID, user)
+	case "move":
+		r.organizerMove(callback.From.ID, callbacks, user)
+	case "pricing":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askPricing(callback.From.ID, matchID, user)
+	case "set_pricing":
+		r.setPricing(callback.From.ID, callbacks, user)
+	case "discounts":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showDiscounts(callback.From.ID, matchID, user)
+	case "set_discount":
+		r.setDiscount(callback.From.ID, callbacks, user)
+	case "expenses":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showExpenses(callback.From.ID, matchID, user)
+	case "expense_add":
+		r.askExpenseAmount(callback.From.ID, callbacks, user)
+	case "expenses_clear":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.clearExpenses(callback.From.ID, matchID, user)
+	case "expenses_split":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.sendCostSplit(callback.From.ID, matchID, user)
+	case "report_publish":
+		reportID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.publishReport(callback.From.ID, reportID, user)
+	case "reports":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showReports(callback.From.ID, matchID)
+	case "teams_edit":
+		matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.showTeamsEditor(callback.From.ID, matchID, user)
+	case "team_rename":
+		teamID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askTeamName(callback.From.ID, teamID, user)
+	case "team_badge":
+		teamID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askTeamBadge(callback.From.ID, teamID, user)
+	case "team_set_badge":
+		r.setTeamBadge(callback.From.ID, callbacks, user)
+	case "team_club":
+		teamID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+		r.askTeamClub(callback.From.ID, teamID, user)
+	case "team_set_club":
+		r.setTeamClub(callback.From.ID, callbacks, user)
+	case "set_language":
+		r.setLanguage(callback.From.ID, callbacks[1], user)
 	}
 }
 
@@ -242,10 +402,10 @@ func (r *router) handleCallback(callback *tgbotapi.CallbackQuery) {
 // 	)
 // }
 
-func matchMoreKeyboard(matchID int64) tgbotapi.InlineKeyboardMarkup {
+func matchMoreKeyboard(lang i18n.Lang, matchID int64) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Подробнее о матче", fmt.Sprintf("get_match_by_id-%d", matchID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.match_more"), fmt.Sprintf("get_match_by_id-%d", matchID)),
 		),
 	)
 }
@@ -254,7 +414,7 @@ func matchTeamsKeyboard(teams []*entity.Team) tgbotapi.InlineKeyboardMarkup {
 	rows := [][]tgbotapi.InlineKeyboardButton{}
 	row := []tgbotapi.InlineKeyboardButton{}
 	for ix, team := range teams {
-		row = append(row, tgbotapi.NewInlineKeyboardButtonData(color[team.Name], fmt.Sprintf("add_team_members-%d", team.ID)))
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(view.TeamLabel(team), fmt.Sprintf("add_team_members-%d", team.ID)))
 		if ix%2 == 1 {
 			rows = append(rows, row)
 			row = []tgbotapi.InlineKeyboardButton{}
@@ -267,89 +427,92 @@ func matchTeamsKeyboard(teams []*entity.Team) tgbotapi.InlineKeyboardMarkup {
 	return mrkup
 }
 
-func (r *router) createMatchCallback(callback *tgbotapi.CallbackQuery, status matches.Status) {
+func (r *router) createMatchCallback(callback *tgbotapi.CallbackQuery, user *entity.User, status matches.Status) {
 	switch status {
 	case matches.StatusNew:
-		r.cache.SetSportType(callback.From.UserName, enum.SportType(callback.Data))
-		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, "Где будет матч?"))
+		r.cache.SetSportType(callback.From.ID, enum.SportType(callback.Data))
+		r.askVenue(callback.From.ID, enum.SportType(callback.Data))
 		return
+	case matches.StatusSportType:
+		r.chooseVenue(callback)
 	case matches.StatusLocation:
-		r.cache.SetDay(callback.From.UserName, enum.MatchDay(callback.Data))
-		msg := tgbotapi.NewMessage(callback.From.ID, "В какое время будет матч?")
+		r.cache.SetDay(callback.From.ID, enum.MatchDay(callback.Data))
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "create.ask_time"))
 		msg.ReplyMarkup = matchTimeKeyboard(enum.MatchDay(callback.Data))
 		r.bot.Send(msg)
 	case matches.StatusMatchDay:
 		hour, _ := strconv.Atoi(callback.Data)
-		r.cache.SetTime(callback.From.UserName, time.Duration(hour)*time.Hour)
-		msg := tgbotapi.NewMessage(callback.From.ID, "Длительность матча?")
-		msg.ReplyMarkup = matchDurationKeyboard
+		r.cache.SetTime(callback.From.ID, time.Duration(hour)*time.Hour)
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "create.ask_duration"))
+		msg.ReplyMarkup = matchDurationKeyboard(r.lang(callback.From.ID), r.creatingSport(callback.From.ID))
 		r.bot.Send(msg)
 	case matches.StatusStartTime:
 		mins, _ := strconv.Atoi(callback.Data)
-		r.cache.SetDuration(callback.From.UserName, time.Duration(mins)*time.Minute)
-		msg := tgbotapi.NewMessage(callback.From.ID, "Сколько человек в команде?")
-		msg.ReplyMarkup = matchTeamSizeKeyboard
+		r.cache.SetDuration(callback.From.ID, time.Duration(mins)*time.Minute)
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "create.ask_team_size"))
+		msg.ReplyMarkup = matchTeamSizeKeyboard(r.creatingSport(callback.From.ID))
 		r.bot.Send(msg)
 	case matches.StatusDuration:
 		teamSize, _ := strconv.Atoi(callback.Data)
-		r.cache.SetTeamSize(callback.From.UserName, int64(teamSize))
-		msg := tgbotapi.NewMessage(callback.From.ID, "Сколько команд?")
-		msg.ReplyMarkup = matchTeamCountKeyboard
+		r.cache.SetTeamSize(callback.From.ID, int64(teamSize))
+		msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "create.ask_team_count"))
+		msg.ReplyMarkup = matchTeamCountKeyboard(r.creatingSport(callback.From.ID))
 		r.bot.Send(msg)
 	case matches.StatusTeamSize:
 		teamCount, _ := strconv.Atoi(callback.Data)
-		r.cache.SetTeamCount(callback.From.UserName, int64(teamCount))
-		msg := tgbotapi.NewMessage(callback.From.ID, "Сколько стоит аренда?")
-		r.bot.Send(msg)
+		r.cache.SetTeamCount(callback.From.ID, int64(teamCount))
+		r.askRent(callback.From.ID)
+	case matches.StatusTeamCount:
+		rent, err := strconv.ParseInt(strings.TrimPrefix(callback.Data, "rent-"), 10, 64)
+		if err != nil {
+			return
+		}
+		r.setRent(callback.From.ID, rent)
 	case matches.StatusRent:
 		isPrivate := false
 		if callback.Data == "закрытый" {
 			isPrivate = true
 		}
-		r.cache.SetPrivate(callback.From.UserName, isPrivate)
-		msg := tgbotapi.NewMessage(callback.From.ID, "Организовать матч?")
-		msg.ReplyMarkup = matchConfirmKeyboard
-		r.bot.Send(msg)
+		r.cache.SetPrivate(callback.From.ID, isPrivate)
+		r.askConfirmMatch(callback.From.ID)
 	case matches.StatusPrivate:
+		if strings.HasPrefix(callback.Data, "slot-") {
+			r.moveNewMatch(callback.From.ID, callback.Data)
+			return
+		}
 		confirmed := true
 		if callback.Data == "отменить" {
 			confirmed = false
 		}
 		if confirmed {
-			match, _ := r.cache.GetMatch(callback.From.UserName)
-			user, err := r.service.GetUserByUsername(context.Background(), callback.From.UserName)
-			if err != nil {
-				user, err = r.service.CreateUser(context.Background(), &entity.User{
-					Name:     callback.From.FirstName,
-					Username: callback.From.UserName,
-					ChatID:   int(callback.From.ID),
-				})
-				if err != nil {
-					log.Println(err)
-					return
-				}
-			}
+			match, _ := r.cache.GetMatch(callback.From.ID)
 			match.OrganizerID = user.ID
-			match, err = r.service.CreateMatch(context.Background(), match)
+			match, err := r.service.CreateMatch(context.Background(), match)
+			if errors.Type(err) == errors.Conflict {
+				r.askConfirmMatch(callback.From.ID)
+				return
+			}
 			if err != nil {
 				log.Println(err)
 				return
 			}
-			match.OrganizerUsername = user.Username
-			msg := tgbotapi.NewMessage(callback.From.ID, fmt.Sprint(match))
-			msg.ReplyMarkup = matchOptionsKeyboard(match.ID)
+			match.OrganizerName = user.DisplayName()
+			lang := r.lang(callback.From.ID)
+			msg := tgbotapi.NewMessage(callback.From.ID, view.Card(lang, match, user.ID))
+			msg.ParseMode = tgbotapi.ModeHTML
+			msg.ReplyMarkup = matchOptionsKeyboard(lang, match.ID)
 			r.bot.Send(msg)
 		}
-		r.cache.DeleteMatch(callback.From.UserName)
+		r.cache.DeleteMatch(callback.From.ID)
 	default:
 	}
 }
 
-func matchOptionsKeyboard(matchID int64) tgbotapi.InlineKeyboardMarkup {
+func matchOptionsKeyboard(lang i18n.Lang, matchID int64) tgbotapi.InlineKeyboardMarkup {
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonData("Добавить участников", fmt.Sprintf("add_members-%d", matchID)),
-			tgbotapi.NewInlineKeyboardButtonData("Отменить матч", fmt.Sprintf("cancel_match-%d", matchID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.add_members"), fmt.Sprintf("add_members-%d", matchID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.cancel_match"), fmt.Sprintf("cancel_match-%d", matchID)),
 		),
 	)
 }
@@ -361,66 +524,82 @@ func matchOptionsKeyboard(matchID int64) tgbotapi.InlineKeyboardMarkup {
 // 	),
 // )
 
-func (r *router) handleMessage(msg *tgbotapi.Message) {
+func (r *router) handleMessage(msg *tgbotapi.Message, user *entity.User) {
+	// in groups linked to matches the bot only listens for the command
+	// linking them
+	if !msg.Chat.IsPrivate() {
+		if msg.Command() == "link_chat" {
+			r.linkMatchChat(msg, user)
+		}
+		return
+	}
 	if msg.IsCommand() {
-		r.handleCommand(msg)
+		r.handleCommand(msg, user)
 		return
 	}
-	status, err := r.cache.GetStatus(msg.From.UserName)
+	status, err := r.cache.GetStatus(msg.From.ID)
 	if err == nil {
 		switch status {
 		case matches.StatusSportType:
-			r.cache.SetLocation(msg.From.UserName, msg.Text)
-			msgToSend := tgbotapi.NewMessage(msg.From.ID, "В какой день будет матч?")
-			msgToSend.ReplyMarkup = matchDayKeyboard
-			r.bot.Send(msgToSend)
+			r.setMatchVenue(msg, user)
 		case matches.StatusTeamCount:
 			rent, _ := strconv.Atoi(msg.Text)
-			r.cache.SetRent(msg.From.UserName, int64(rent))
-			msgToSend := tgbotapi.NewMessage(msg.From.ID, "Закрытый или открытый матч?")
-			msgToSend.ReplyMarkup = matchPrivateKeyboard
-			r.bot.Send(msgToSend)
+			r.setRent(msg.From.ID, int64(rent))
 		}
 	}
-	userStatus := r.userCache.GetStatus(msg.From.UserName)
+	if err != nil && r.chatMessage(msg, user) {
+		return
+	}
+	userStatus := r.userCache.GetStatus(msg.From.ID)
 	switch userStatus {
 	case users.StatusAddTeamMembers:
-		r.addTeamMembers(msg)
+		r.addTeamMembers(msg, user)
 	case users.StatusSendReport:
-		r.sendReport(msg)
+		r.sendReport(msg, user)
+	case users.StatusFilterLocation:
+		r.setLocationFilter(msg)
+	case users.StatusFilterNear:
+		r.setNearFilter(msg)
+	case users.StatusVenueLocation:
+		r.setVenueLocation(msg, user)
+	case users.StatusVenuePhoto:
+		r.setVenuePhoto(msg, user)
+	case users.StatusMatchScore:
+		r.setScore(msg)
+	case users.StatusSpotTransfer:
+		r.offerSpot(msg, user)
+	case users.StatusTeamName:
+		r.renameTeam(msg, user)
+	case users.StatusExpenseAmount:
+		r.addExpense(msg, user)
+	case users.StatusFixedPrice:
+		r.setFixedPrice(msg)
+	case users.StatusBirthYear:
+		r.setBirthYear(msg, user)
+	default:
+		if err != nil && (msg.Location != nil || msg.Venue != nil) {
+			r.setNearFilter(msg)
+		}
 	}
 }
 
-func (r *router) sendReport(msg *tgbotapi.Message) {
-	user, ok := r.userCache.GetUser(msg.From.UserName)
+func (r *router) addTeamMembers(msg *tgbotapi.Message, organizer *entity.User) {
+	invitees := parseInvitees(msg)
+	cached, ok := r.userCache.GetUser(msg.From.ID)
 	if !ok {
 		log.Println("user not found in cache")
 		return
 	}
-	match, _ := r.service.GetMatchByMatchID(context.Background(), user.MatchID)
-	for _, team := range match.Teams {
-		for _, member := range team.Members {
-			msgToSend := tgbotapi.NewMessage(int64(member.ChatID), fmt.Sprintf("Отчет по матчу #%d", user.MatchID))
-			msgToSend.ReplyMarkup = matchMoreKeyboard(match.ID)
-			r.bot.Send(msgToSend)
-			photoToSend := tgbotapi.NewPhoto(int64(member.ChatID), tgbotapi.FileID(msg.Photo[0].FileID))
-			r.bot.Send(photoToSend)
-		}
-	}
-}
-
-func (r *router) addTeamMembers(msg *tgbotapi.Message) {
-	members := strings.Fields(msg.Text)
-	user, ok := r.userCache.GetUser(msg.From.UserName)
-	if !ok {
-		log.Println("user not found in cache")
+	result, err := r.service.AddTeamMembers(context.Background(), cached.TeamID, organizer.ID, invitees)
+	if errors.Type(err) == errors.Conflict {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "invite.filled")))
 		return
 	}
-	if err := r.service.AddTeamMembers(context.Background(), user.TeamID, members); err != nil {
+	if err != nil {
 		log.Println(err)
 		return
 	}
-	matchID, err := r.service.GetMatchIDByTeamID(context.Background(), user.TeamID)
+	matchID, err := r.service.GetMatchIDByTeamID(context.Background(), cached.TeamID)
 	if err != nil {
 		log.Println(err)
 		return
@@ -430,93 +609,174 @@ func (r *router) addTeamMembers(msg *tgbotapi.Message) {
 		log.Println(err)
 		return
 	}
-	users := r.service.GetUsersByUsernames(context.Background(), members)
-	for _, user := range users {
-		r.bot.Send(tgbotapi.NewMessage(int64(user.ChatID), "Вас приглашают на матч"))
-		msgToSend := tgbotapi.NewMessage(int64(user.ChatID), fmt.Sprint(match))
-		msgToSend.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
-			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData("Отменить участие", fmt.Sprintf("signout_match-%d", matchID)),
-				tgbotapi.NewInlineKeyboardButtonData("Подтвердить участие", fmt.Sprintf("confirm_match-%d", matchID)),
-			))
-		r.bot.Send(msgToSend)
+	for _, user := range result.Added {
+		r.sendInvitation(user, match)
+	}
+	r.userCache.SetStatus(cached.TelegramID, 0)
+	r.bot.Send(tgbotapi.NewMessage(msg.From.ID, inviteReport(r.lang(msg.From.ID), result)))
+}
+
+func (r *router) sendInvitation(user *entity.User, match *entity.Match) {
+	lang := view.Lang(user)
+	msgToSend := tgbotapi.NewMessage(user.ChatID, view.Notification(lang, i18n.T(lang, "invitation.received"), match, user.ID))
+	msgToSend.ParseMode = tgbotapi.ModeHTML
+	msgToSend.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.signout"), fmt.Sprintf("signout_match-%d", match.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "button.confirm"), fmt.Sprintf("confirm_match-%d", match.ID)),
+		))
+	r.notifier.Notify(user, enum.NotificationInvitation, msgToSend)
+	r.inviteToMatchChat(match, user)
+}
+
+// claimInvitations lets the newcomer and their inviters know about the
+// matches they were added to by the invitations they claimed.
+func (r *router) claimInvitations(user *entity.User, invitations []*entity.Invitation) {
+	for _, invitation := range invitations {
+		match, err := r.service.GetMatchByMatchID(context.Background(), invitation.MatchID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		r.sendInvitation(user, match)
+		inviter := &entity.User{ID: invitation.InvitedBy, ChatID: invitation.InviterChatID, Language: invitation.InviterLanguage}
+		r.notifier.Notify(inviter, enum.NotificationSignUp, tgbotapi.NewMessage(inviter.ChatID,
+			i18n.T(view.Lang(inviter), "invitation.claimed", user.DisplayName(), match.ID)))
+	}
+}
+
+func inviteReport(lang i18n.Lang, result *entity.InviteResult) string {
+	if len(result.Added) == 0 && len(result.Pending) == 0 && len(result.Invalid) == 0 &&
+		len(result.Full) == 0 && len(result.AlreadyIn) == 0 {
+		return i18n.T(lang, "invite.nobody")
 	}
-	r.userCache.SetStatus(user.Username, 0)
-	//TODO:respond successfully
+	out := ""
+	if len(result.Added) != 0 {
+		names := lo.Map(result.Added, func(item *entity.User, _ int) string {
+			return item.DisplayName()
+		})
+		out += i18n.T(lang, "invite.added", strings.Join(names, ", ")) + "\n"
+	}
+	if len(result.Pending) != 0 {
+		out += i18n.T(lang, "invite.pending", strings.Join(result.Pending, ", ")) + "\n"
+	}
+	if len(result.Invalid) != 0 {
+		out += i18n.T(lang, "invite.invalid", strings.Join(result.Invalid, ", ")) + "\n"
+	}
+	if len(result.AlreadyIn) != 0 {
+		out += i18n.T(lang, "invite.already_in", strings.Join(result.AlreadyIn, ", ")) + "\n"
+	}
+	if len(result.Full) != 0 {
+		out += i18n.T(lang, "invite.full", strings.Join(result.Full, ", ")) + "\n"
+	}
+	return out
 }
 
-func (r *router) handleCommand(msg *tgbotapi.Message) {
+// parseInvitees collects everyone an organizer pointed at in a message:
+// @mentions, text mentions of users without a username, a shared contact
+// and the author of a forwarded message.
+func parseInvitees(msg *tgbotapi.Message) []*entity.Invitee {
+	var invitees []*entity.Invitee
+	text := utf16.Encode([]rune(msg.Text))
+	for _, e := range msg.Entities {
+		switch {
+		case e.IsMention():
+			if e.Offset+e.Length > len(text) {
+				continue
+			}
+			invitees = append(invitees, &entity.Invitee{
+				Username: string(utf16.Decode(text[e.Offset : e.Offset+e.Length])),
+			})
+		case e.Type == "text_mention" && e.User != nil:
+			invitees = append(invitees, inviteeFromUser(e.User))
+		}
+	}
+	if msg.Contact != nil && msg.Contact.UserID != 0 {
+		invitees = append(invitees, &entity.Invitee{
+			TelegramID: msg.Contact.UserID,
+			Name:       msg.Contact.FirstName,
+		})
+	}
+	if msg.ForwardFrom != nil {
+		invitees = append(invitees, inviteeFromUser(msg.ForwardFrom))
+	}
+	return invitees
+}
+
+func inviteeFromUser(user *tgbotapi.User) *entity.Invitee {
+	return &entity.Invitee{
+		TelegramID: user.ID,
+		Username:   user.UserName,
+		Name:       user.FirstName,
+	}
+}
+
+func (r *router) handleCommand(msg *tgbotapi.Message, user *entity.User) {
 	cmd := msg.Command()
 	switch cmd {
 	case "create_match":
-		r.cache.SetMatch(msg.From.UserName)
-		msgToSend := tgbotapi.NewMessage(msg.From.ID, "Выберите вид спорта")
-		msgToSend.ReplyMarkup = sportTypeKeyboard
+		r.cache.SetMatch(msg.From.ID)
+		msgToSend := tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "sport.ask"))
+		msgToSend.ReplyMarkup = r.sportKeyboard(r.lang(msg.From.ID), "")
 		r.bot.Send(msgToSend)
+	case "venues":
+		r.showVenues(msg.From.ID, user)
+	case "stats":
+		r.showStats(msg.From.ID, user)
+	case "language":
+		r.askLanguage(msg.From.ID)
+	case "sport":
+		r.manageSports(msg)
+	case "profile":
+		r.showProfile(msg.From.ID, user)
+	case "notifications":
+		r.showNotificationSettings(msg.From.ID, user)
+	case "link_chat":
+		r.linkMatchChat(msg, user)
 	case "get_matches":
-		msgToSend := tgbotapi.NewMessage(msg.From.ID, "Выберите вид спорта")
-		msgToSend.ReplyMarkup = sportTypeCommandKeyboard
+		msgToSend := tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "sport.ask"))
+		msgToSend.ReplyMarkup = r.sportKeyboard(r.lang(msg.From.ID), "get_matches_by_sport-")
 		r.bot.Send(msgToSend)
 	case "my_matches":
-		user, _ := r.service.GetUserByUsername(context.Background(), msg.From.UserName)
 		matches, _ := r.service.GetMatchesByUserID(context.Background(), user.ID)
-		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, `🔜 Ближайшие ваши матчи
-		`))
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "matches.mine")))
 		if len(matches) == 0 {
-			r.bot.Send(tgbotapi.NewMessage(msg.From.ID, `😥 К сожалению, матчей нет`))
+			r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "matches.none")))
 			return
 		}
+		lang := r.lang(msg.From.ID)
 		for _, m := range matches {
-			msg := tgbotapi.NewMessage(msg.From.ID,
-				fmt.Sprintf(`Матч #%d - Начало %d/%d %d:00(%.1f часа) - %d тг/чел - Осталось %d мест`,
-					m.ID, m.StartAt.Day(), m.StartAt.Month(), m.StartAt.Hour(), float64(m.FinishAt.Sub(m.StartAt).Minutes())/60.0,
-					m.Rent/(m.TeamCount*m.TeamSize), (m.TeamCount*m.TeamSize)-m.MembersCount))
-			msg.ReplyMarkup = matchMoreKeyboard(m.ID)
+			msg := tgbotapi.NewMessage(msg.From.ID, view.Listing(lang, m))
+			msg.ParseMode = tgbotapi.ModeHTML
+			msg.ReplyMarkup = matchMoreKeyboard(lang, m.ID)
 			r.bot.Send(msg)
 		}
 
 	case "organized_matches":
-		user, _ := r.service.GetUserByUsername(context.Background(), msg.From.UserName)
 		matches, _ := r.service.GetMatchesByUserID(context.Background(), user.ID)
-		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, `🔜 Матчи организованные вами
-		`))
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "matches.organized")))
 		if len(matches) == 0 {
-			r.bot.Send(tgbotapi.NewMessage(msg.From.ID, `😥 К сожалению, матчей нет`))
+			r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "matches.none")))
 			return
 		}
+		lang := r.lang(msg.From.ID)
 		for _, m := range matches {
-			msg := tgbotapi.NewMessage(msg.From.ID,
-				fmt.Sprintf(`Матч #%d - Начало %d/%d %d:00(%.1f часа) - %d тг/чел - Осталось %d мест`,
-					m.ID, m.StartAt.Day(), m.StartAt.Month(), m.StartAt.Hour(), float64(m.FinishAt.Sub(m.StartAt).Minutes())/60.0,
-					m.Rent/(m.TeamCount*m.TeamSize), (m.TeamCount*m.TeamSize)-m.MembersCount))
-			msg.ReplyMarkup = matchMoreKeyboard(m.ID)
+			msg := tgbotapi.NewMessage(msg.From.ID, view.Listing(lang, m))
+			msg.ParseMode = tgbotapi.ModeHTML
+			msg.ReplyMarkup = matchMoreKeyboard(lang, m.ID)
 			r.bot.Send(msg)
 		}
 	}
 }
 
-var sportTypeCommandKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("football", "get_matches_by_sport-football"),
-		tgbotapi.NewInlineKeyboardButtonData("volleyball", "get_matches_by_sport-volleyball"),
-		tgbotapi.NewInlineKeyboardButtonData("basketball", "get_matches_by_sport-basketball"),
-	),
-)
-
-var sportTypeKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("football", "football"),
-		tgbotapi.NewInlineKeyboardButtonData("volleyball", "volleyball"),
-		tgbotapi.NewInlineKeyboardButtonData("basketball", "basketball"),
-	),
-)
-
-var matchDayKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("сегодня", "today"),
-		tgbotapi.NewInlineKeyboardButtonData("завтра", "tomorrow"),
-	),
-)
+func matchDayKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "day.today"), "today"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "day.tomorrow"), "tomorrow"),
+		),
+	)
+}
 
 func matchTimeKeyboard(day enum.MatchDay) tgbotapi.InlineKeyboardMarkup {
 	switch day {
@@ -564,57 +824,20 @@ var matchTimeRows = [][]tgbotapi.InlineKeyboardButton{
 	),
 }
 
-var matchDurationKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("1 час", "60"),
-		tgbotapi.NewInlineKeyboardButtonData("1.5 часа", "90"),
-		tgbotapi.NewInlineKeyboardButtonData("2 часа", "120"),
-	),
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("2.5 часа", "150"),
-		tgbotapi.NewInlineKeyboardButtonData("3 часа", "180"),
-		tgbotapi.NewInlineKeyboardButtonData("3.5 часа", "210"),
-	),
-)
-
-var matchTeamSizeKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("4", "4"),
-		tgbotapi.NewInlineKeyboardButtonData("5", "5"),
-		tgbotapi.NewInlineKeyboardButtonData("6", "6"),
-	),
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("7", "7"),
-		tgbotapi.NewInlineKeyboardButtonData("8", "8"),
-		tgbotapi.NewInlineKeyboardButtonData("9", "9"),
-	),
-)
-
-var matchTeamCountKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("2", "2"),
-		tgbotapi.NewInlineKeyboardButtonData("3", "3"),
-		tgbotapi.NewInlineKeyboardButtonData("4", "4"),
-	),
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("5", "5"),
-		tgbotapi.NewInlineKeyboardButtonData("6", "6"),
-		tgbotapi.NewInlineKeyboardButtonData("7", "7"),
-	),
-)
-
-var matchPrivateKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("Открытый", "открытый"),
-		tgbotapi.NewInlineKeyboardButtonData("Закрытый", "закрытый"),
-	),
-)
-
-var matchConfirmKeyboard = tgbotapi.NewInlineKeyboardMarkup(
-	tgbotapi.NewInlineKeyboardRow(
-		tgbotapi.NewInlineKeyboardButtonData("Организовать", "организовать"),
-		tgbotapi.NewInlineKeyboardButtonData("Отменить", "отменить"),
-	),
-)
+func matchPrivateKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "create.public"), "открытый"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "create.private"), "закрытый"),
+		),
+	)
+}
 
-var color = map[string]string{"red": "🟥", "blue": "🟦", "green": "🟩", "yellow": "🟨", "purple": "🟪", "black": "⬛️", "brown": "🟫"}
+func matchConfirmKeyboard(lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "create.organize"), "организовать"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "create.cancel"), "отменить"),
+		),
+	)
+}
@@ -0,0 +1,256 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const matchesPageSize = 5
+
+const (
+	periodToday    = "today"
+	periodTomorrow = "tomorrow"
+	periodWeek     = "week"
+)
+
+// startSearch resets the filters to the chosen sport and shows the first page.
+func (r *router) startSearch(telegramID int64, sport enum.SportType) {
+	r.userCache.SetSearch(telegramID, users.Search{Filter: entity.MatchFilter{Sport: sport}})
+	r.showMatches(telegramID)
+}
+
+// handleFilterCallback applies a "filter-<field>-<value>" button press.
+func (r *router) handleFilterCallback(callback *tgbotapi.CallbackQuery, callbacks []string, user *entity.User) {
+	if len(callbacks) < 2 {
+		return
+	}
+	search := r.userCache.GetSearch(callback.From.ID)
+	value := ""
+	if len(callbacks) > 2 {
+		value = callbacks[2]
+	}
+	number, _ := strconv.ParseInt(value, 10, 64)
+	switch callbacks[1] {
+	case "period":
+		search.Period = value
+	case "price":
+		search.Filter.MaxPrice = number
+	case "places":
+		search.Filter.MinFreePlaces = number
+	case "format":
+		search.Filter.TeamSize = number
+	case "order":
+		search.Filter.Descending = !search.Filter.Descending
+	case "gender":
+		search.Filter.Gender = enum.Gender(value)
+	case "eligible":
+		if search.Filter.Eligible != nil {
+			search.Filter.Eligible = nil
+			break
+		}
+		profile, err := r.service.GetProfile(context.Background(), user.ID, search.Filter.Sport)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		search.Filter.Eligible = profile
+	case "near":
+		r.askNearLocation(callback.From.ID)
+		return
+	case "location":
+		r.userCache.SetStatus(callback.From.ID, users.StatusFilterLocation)
+		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "search.ask_location")))
+		return
+	case "reset":
+		search = users.Search{Filter: entity.MatchFilter{Sport: search.Filter.Sport}}
+	case "page":
+		search.Filter.Offset = int(number) * matchesPageSize
+		r.userCache.SetSearch(callback.From.ID, search)
+		r.showMatches(callback.From.ID)
+		return
+	}
+	search.Filter.Offset = 0
+	r.userCache.SetSearch(callback.From.ID, search)
+	r.showMatches(callback.From.ID)
+}
+
+func (r *router) setLocationFilter(msg *tgbotapi.Message) {
+	search := r.userCache.GetSearch(msg.From.ID)
+	search.Filter.Location = strings.TrimSpace(msg.Text)
+	search.Filter.Offset = 0
+	r.userCache.SetSearch(msg.From.ID, search)
+	r.userCache.SetStatus(msg.From.ID, 0)
+	r.showMatches(msg.From.ID)
+}
+
+// showMatches sends the current page of matches for the player's search
+// followed by the filter and pagination keyboard.
+func (r *router) showMatches(telegramID int64) {
+	search := r.userCache.GetSearch(telegramID)
+	filter := search.Filter
+	filter.From, filter.To = periodRange(search.Period, time.Now())
+	filter.Limit = matchesPageSize + 1
+	matches, err := r.service.SearchMatches(context.Background(), &filter)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	hasNext := len(matches) > matchesPageSize
+	if hasNext {
+		matches = matches[:matchesPageSize]
+	}
+	lang := r.lang(telegramID)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, i18n.T(lang, "search.title",
+		r.sportName(lang, filter.Sport), filterSummary(lang, search))))
+	if len(matches) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, i18n.T(lang, "matches.none")))
+	}
+	for _, m := range matches {
+		msg := tgbotapi.NewMessage(telegramID, view.Listing(lang, m))
+		msg.ParseMode = tgbotapi.ModeHTML
+		msg.ReplyMarkup = matchMoreKeyboard(lang, m.ID)
+		r.bot.Send(msg)
+	}
+	sport, err := r.service.GetSport(context.Background(), filter.Sport)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, i18n.T(lang, "search.filters"))
+	msg.ReplyMarkup = filterKeyboard(lang, search, sport, hasNext)
+	r.bot.Send(msg)
+}
+
+// periodRange turns a period chosen on the keyboard into a [from, to) range.
+func periodRange(period string, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case periodToday:
+		return today, today.AddDate(0, 0, 1)
+	case periodTomorrow:
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case periodWeek:
+		return today, today.AddDate(0, 0, 7)
+	default:
+		return time.Time{}, time.Time{}
+	}
+}
+
+func filterSummary(lang i18n.Lang, search users.Search) string {
+	var parts []string
+	switch search.Period {
+	case periodToday:
+		parts = append(parts, "📅 "+i18n.T(lang, "day.today"))
+	case periodTomorrow:
+		parts = append(parts, "📅 "+i18n.T(lang, "day.tomorrow"))
+	case periodWeek:
+		parts = append(parts, "📅 "+i18n.T(lang, "day.week"))
+	}
+	if search.Filter.MaxPrice > 0 {
+		parts = append(parts, i18n.T(lang, "filter.max_price", search.Filter.MaxPrice))
+	}
+	if search.Filter.MinFreePlaces > 0 {
+		parts = append(parts, i18n.N(lang, "filter.min_places", search.Filter.MinFreePlaces, search.Filter.MinFreePlaces))
+	}
+	if search.Filter.TeamSize > 0 {
+		parts = append(parts, fmt.Sprintf("👥 %dvs%d", search.Filter.TeamSize, search.Filter.TeamSize))
+	}
+	if search.Filter.Location != "" {
+		parts = append(parts, "📍 "+search.Filter.Location)
+	}
+	if search.Filter.Near != nil {
+		parts = append(parts, i18n.T(lang, "filter.radius", search.Filter.RadiusKm))
+	}
+	if search.Filter.Gender != enum.GenderAny {
+		parts = append(parts, "🎯 "+i18n.T(lang, "gender."+string(search.Filter.Gender)))
+	}
+	if search.Filter.Eligible != nil {
+		parts = append(parts, "🎯 "+i18n.T(lang, "filter.eligible"))
+	}
+	if len(parts) == 0 {
+		return i18n.T(lang, "filter.none")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func filterKeyboard(lang i18n.Lang, search users.Search, sport *entity.Sport, hasNext bool) tgbotapi.InlineKeyboardMarkup {
+	option := func(label, field, value string, selected bool) tgbotapi.InlineKeyboardButton {
+		if selected {
+			label = "✓ " + label
+		}
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("filter-%s-%s", field, value))
+	}
+	filter := search.Filter
+	order := i18n.T(lang, "filter.nearest_first")
+	if filter.Descending {
+		order = i18n.T(lang, "filter.latest_first")
+	}
+	price := func(max int64) tgbotapi.InlineKeyboardButton {
+		return option(i18n.T(lang, "filter.price_option", max), "price", strconv.FormatInt(max, 10), filter.MaxPrice == max)
+	}
+	places := func(min int64) tgbotapi.InlineKeyboardButton {
+		return option(i18n.N(lang, "filter.places_option", min, min), "places", strconv.FormatInt(min, 10), filter.MinFreePlaces == min)
+	}
+	// the formats are the team sizes the sport is played in
+	formats := []tgbotapi.InlineKeyboardButton{}
+	for _, size := range sport.TeamSizes {
+		formats = append(formats, option(fmt.Sprintf("%dvs%d", size, size), "format", strconv.FormatInt(size, 10), filter.TeamSize == size))
+	}
+	formats = append(formats, option(i18n.T(lang, "filter.any_format"), "format", "0", filter.TeamSize == 0))
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			option(i18n.T(lang, "filter.today"), "period", periodToday, search.Period == periodToday),
+			option(i18n.T(lang, "filter.tomorrow"), "period", periodTomorrow, search.Period == periodTomorrow),
+			option(i18n.T(lang, "filter.week"), "period", periodWeek, search.Period == periodWeek),
+			option(i18n.T(lang, "filter.any_day"), "period", "", search.Period == ""),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			price(1000),
+			price(2000),
+			price(5000),
+			option(i18n.T(lang, "filter.any_price"), "price", "0", filter.MaxPrice == 0),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			places(1),
+			places(2),
+			places(5),
+			option(i18n.T(lang, "filter.any_places"), "places", "0", filter.MinFreePlaces == 0),
+		),
+		formats,
+		tgbotapi.NewInlineKeyboardRow(
+			option(i18n.T(lang, "gender.female"), "gender", string(enum.GenderFemale), filter.Gender == enum.GenderFemale),
+			option(i18n.T(lang, "gender.male"), "gender", string(enum.GenderMale), filter.Gender == enum.GenderMale),
+			option(i18n.T(lang, "filter.any_gender"), "gender", "", filter.Gender == enum.GenderAny),
+			option(i18n.T(lang, "filter.eligible"), "eligible", "", filter.Eligible != nil),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			option(i18n.T(lang, "filter.location"), "location", "", filter.Location != ""),
+			option(i18n.T(lang, "filter.near"), "near", "", filter.Near != nil),
+			tgbotapi.NewInlineKeyboardButtonData(order, "filter-order"),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "filter.reset"), "filter-reset"),
+		),
+	}
+	page := filter.Offset / matchesPageSize
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("⬅️", fmt.Sprintf("filter-page-%d", page-1)))
+	}
+	if hasNext {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("➡️", fmt.Sprintf("filter-page-%d", page+1)))
+	}
+	if len(nav) != 0 {
+		rows = append(rows, nav)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
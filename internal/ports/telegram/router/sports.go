@@ -0,0 +1,181 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/samber/lo"
+)
+
+// fallbackSport holds the defaults used when the sport of a match being
+// created can't be loaded.
+var fallbackSport = &entity.Sport{TeamSizes: []int64{4, 5, 6, 7, 8, 9}, DurationMinutes: 90, TeamCount: 2}
+
+var (
+	matchDurations  = []int64{60, 90, 120, 150, 180, 210}
+	matchTeamCounts = []int64{2, 3, 4, 5, 6, 7}
+)
+
+// sportKeyboard offers the sports, the data of each button being the sport
+// prefixed with the callback.
+func (r *router) sportKeyboard(lang i18n.Lang, callback string) tgbotapi.InlineKeyboardMarkup {
+	sports, err := r.service.GetSports(context.Background())
+	if err != nil {
+		log.Println(err)
+	}
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(sports))
+	for _, sport := range sports {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(view.SportLabel(lang, sport), callback+string(sport.Code)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(lo.Chunk(buttons, 3)...)
+}
+
+// sportName is the name of the sport in the language, or its code when
+// the sport can't be loaded.
+func (r *router) sportName(lang i18n.Lang, code enum.SportType) string {
+	sport, err := r.service.GetSport(context.Background(), code)
+	if err != nil {
+		log.Println(err)
+		return string(code)
+	}
+	return view.SportName(lang, sport)
+}
+
+// creatingSport is the sport of the match the user is creating.
+func (r *router) creatingSport(telegramID int64) *entity.Sport {
+	m, err := r.cache.GetMatch(telegramID)
+	if err != nil {
+		log.Println(err)
+		return fallbackSport
+	}
+	sport, err := r.service.GetSport(context.Background(), m.Type)
+	if err != nil {
+		log.Println(err)
+		return fallbackSport
+	}
+	return sport
+}
+
+// numberKeyboard lays the numbers out three in a row, marking the default.
+func numberKeyboard(numbers []int64, def int64, label func(int64) string) tgbotapi.InlineKeyboardMarkup {
+	buttons := make([]tgbotapi.InlineKeyboardButton, 0, len(numbers))
+	for _, n := range numbers {
+		text := label(n)
+		if n == def {
+			text = "✓ " + text
+		}
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(text, strconv.FormatInt(n, 10)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(lo.Chunk(buttons, 3)...)
+}
+
+// matchDurationKeyboard offers the usual durations along with the typical
+// one for the sport.
+func matchDurationKeyboard(lang i18n.Lang, sport *entity.Sport) tgbotapi.InlineKeyboardMarkup {
+	durations := lo.Uniq(append(append([]int64{}, matchDurations...), sport.DurationMinutes))
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return numberKeyboard(durations, sport.DurationMinutes, func(minutes int64) string {
+		return i18n.T(lang, "duration.hours", float64(minutes)/60)
+	})
+}
+
+func matchTeamSizeKeyboard(sport *entity.Sport) tgbotapi.InlineKeyboardMarkup {
+	return numberKeyboard(sport.TeamSizes, 0, func(n int64) string { return strconv.FormatInt(n, 10) })
+}
+
+func matchTeamCountKeyboard(sport *entity.Sport) tgbotapi.InlineKeyboardMarkup {
+	counts := lo.Uniq(append(append([]int64{}, matchTeamCounts...), sport.TeamCount))
+	sort.Slice(counts, func(i, j int) bool { return counts[i] < counts[j] })
+	return numberKeyboard(counts, sport.TeamCount, func(n int64) string { return strconv.FormatInt(n, 10) })
+}
+
+func (r *router) isAdmin(telegramID int64) bool {
+	return lo.Contains(r.adminIDs, telegramID)
+}
+
+// manageSports lists the sports for "/sport" and adds or updates one for
+// "/sport code emoji sizes minutes teams names", the names being given in
+// the order of i18n.Langs and separated by "|".
+func (r *router) manageSports(msg *tgbotapi.Message) {
+	if !r.isAdmin(msg.From.ID) {
+		return
+	}
+	args := strings.TrimSpace(msg.CommandArguments())
+	if args == "" {
+		r.showSports(msg.From.ID)
+		return
+	}
+	sport, ok := parseSport(args)
+	if !ok {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "sport.usage")))
+		return
+	}
+	err := r.service.SaveSport(context.Background(), sport)
+	if errors.Type(err) == errors.Invalid {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "sport.invalid", err.Error())))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "sport.saved", view.SportLabel(r.lang(msg.From.ID), sport))))
+	r.showSports(msg.From.ID)
+}
+
+func (r *router) showSports(telegramID int64) {
+	sports, err := r.service.GetSports(context.Background())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := r.lang(telegramID)
+	out := i18n.T(lang, "sport.list")
+	for _, sport := range sports {
+		sizes := lo.Map(sport.TeamSizes, func(n int64, _ int) string { return strconv.FormatInt(n, 10) })
+		names := lo.Map(i18n.Langs, func(l i18n.Lang, _ int) string { return sport.Names[string(l)] })
+		out += fmt.Sprintf("\n\n%s\n/sport %s %s %s %d %d %s", view.SportLabel(lang, sport), sport.Code, sport.Emoji,
+			strings.Join(sizes, ","), sport.DurationMinutes, sport.TeamCount, strings.Join(names, "|"))
+	}
+	out += "\n\n" + i18n.T(lang, "sport.usage")
+	r.bot.Send(tgbotapi.NewMessage(telegramID, out))
+}
+
+func parseSport(args string) (*entity.Sport, bool) {
+	fields := strings.Fields(args)
+	if len(fields) < 6 {
+		return nil, false
+	}
+	sport := &entity.Sport{Code: enum.SportType(strings.ToLower(fields[0])), Emoji: fields[1], Names: map[string]string{}}
+	for _, s := range strings.Split(fields[2], ",") {
+		size, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		sport.TeamSizes = append(sport.TeamSizes, size)
+	}
+	var err error
+	if sport.DurationMinutes, err = strconv.ParseInt(fields[3], 10, 64); err != nil {
+		return nil, false
+	}
+	if sport.TeamCount, err = strconv.ParseInt(fields[4], 10, 64); err != nil {
+		return nil, false
+	}
+	names := strings.Split(strings.Join(fields[5:], " "), "|")
+	for i, lang := range i18n.Langs {
+		if i < len(names) {
+			sport.Names[string(lang)] = strings.TrimSpace(names[i])
+		}
+	}
+	return sport, true
+}
@@ -0,0 +1,244 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// askTeam lets the player pick a team with free places or any of them.
+func (r *router) askTeam(telegramID, matchID int64) {
+	match, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	row := teamChoiceRow(match, "signup_team", nil)
+	if len(row) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "signup.no_places")))
+		return
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "signup.any_team"), fmt.Sprintf("signup_team-%d-0", matchID)))
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "signup.ask_team"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	r.bot.Send(msg)
+}
+
+func (r *router) signUp(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	teamID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	match, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if teamID == 0 {
+		min := match.TeamSize
+		for _, team := range match.Teams {
+			if team.HasRoom() && int64(len(team.Members)) < min {
+				teamID = team.ID
+				min = int64(len(team.Members))
+			}
+		}
+		if teamID == 0 {
+			r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "signup.no_places")))
+			return
+		}
+	}
+	err = r.service.SignUpToMatch(context.Background(), user.ID, teamID)
+	switch errors.Type(err) {
+	case errors.Forbidden:
+		r.refuseSignUp(telegramID, match, user)
+		return
+	case errors.Conflict:
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "signup.conflict")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	organizer, err := r.service.GetUserByID(context.Background(), match.OrganizerID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "signup.done"))
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(telegramID), match.ID)
+	r.bot.Send(msg)
+	r.inviteToMatchChat(match, user)
+	msg = tgbotapi.NewMessage(organizer.ChatID, i18n.T(view.Lang(organizer), "signup.organizer", user.DisplayName(), matchID))
+	msg.ReplyMarkup = matchMoreKeyboard(view.Lang(organizer), match.ID)
+	r.notifier.Notify(organizer, enum.NotificationSignUp, msg)
+}
+
+// askSwitchTeam offers the player the other teams that have free places.
+func (r *router) askSwitchTeam(telegramID, matchID int64, user *entity.User) {
+	match, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	current := match.TeamOf(user.ID)
+	if current == nil {
+		return
+	}
+	row := teamChoiceRow(match, "switch_request", current)
+	if len(row) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "switch.no_places")))
+		return
+	}
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "switch.ask_team"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	r.bot.Send(msg)
+}
+
+// requestSwitch moves the organizer right away and asks the organizer's
+// approval for everyone else.
+func (r *router) requestSwitch(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	teamID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	match, err := r.service.GetMatchByMatchID(context.Background(), matchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	team := match.Team(teamID)
+	if team == nil {
+		return
+	}
+	if match.OrganizerID == user.ID {
+		r.moveMember(telegramID, matchID, user.ID, teamID)
+		return
+	}
+	organizer, err := r.service.GetUserByID(context.Background(), match.OrganizerID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := view.Lang(organizer)
+	msg := tgbotapi.NewMessage(organizer.ChatID, i18n.T(lang, "switch.request",
+		user.DisplayName(), view.TeamLabel(team), matchID))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "switch.approve"), fmt.Sprintf("move-%d-%d-%d", matchID, user.ID, teamID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "switch.reject"), fmt.Sprintf("switch_reject-%d-%d", matchID, user.ID)),
+		),
+	)
+	r.notifier.Notify(organizer, enum.NotificationInvitation, msg)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "switch.requested")))
+}
+
+func (r *router) rejectSwitch(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 3 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	member, err := r.service.GetUserByID(context.Background(), memberID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(member.ChatID, i18n.T(view.Lang(member), "switch.rejected", matchID))
+	msg.ReplyMarkup = matchMoreKeyboard(view.Lang(member), matchID)
+	r.notifier.Notify(member, enum.NotificationInvitation, msg)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "switch.rejected_organizer")))
+}
+
+// showMoves lets the organizer move every player to another team.
+func (r *router) showMoves(telegramID, matchID int64, user *entity.User) {
+	match, ok := r.organizedMatch(matchID, user)
+	if !ok {
+		return
+	}
+	for _, team := range match.Teams {
+		for _, member := range team.Members {
+			row := []tgbotapi.InlineKeyboardButton{}
+			for _, other := range match.Teams {
+				if other.ID == team.ID {
+					continue
+				}
+				row = append(row, tgbotapi.NewInlineKeyboardButtonData("➡️ "+view.TeamLabel(other),
+					fmt.Sprintf("move-%d-%d-%d", matchID, member.ID, other.ID)))
+			}
+			msg := tgbotapi.NewMessage(telegramID, fmt.Sprintf("%s %s", view.TeamLabel(team), member.DisplayName()))
+			msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+			r.bot.Send(msg)
+		}
+	}
+}
+
+func (r *router) organizerMove(telegramID int64, callbacks []string, user *entity.User) {
+	if len(callbacks) < 4 {
+		return
+	}
+	matchID, _ := strconv.ParseInt(callbacks[1], 10, 64)
+	memberID, _ := strconv.ParseInt(callbacks[2], 10, 64)
+	teamID, _ := strconv.ParseInt(callbacks[3], 10, 64)
+	if _, ok := r.organizedMatch(matchID, user); !ok {
+		return
+	}
+	r.moveMember(telegramID, matchID, memberID, teamID)
+}
+
+func (r *router) moveMember(telegramID, matchID, memberID, teamID int64) {
+	match, err := r.service.MoveToTeam(context.Background(), matchID, memberID, teamID)
+	switch errors.Type(err) {
+	case errors.Conflict:
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "move.no_places")))
+		return
+	case errors.Invalid:
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "move.not_in_match")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	team := match.Team(teamID)
+	for _, member := range team.Members {
+		if member.ID != memberID {
+			continue
+		}
+		msg := tgbotapi.NewMessage(member.ChatID, i18n.T(view.Lang(member), "team.moved", view.TeamLabel(team), matchID))
+		msg.ReplyMarkup = matchMoreKeyboard(view.Lang(member), matchID)
+		if member.ChatID == telegramID {
+			r.bot.Send(msg)
+			continue
+		}
+		r.notifier.Notify(member, enum.NotificationMatch, msg)
+		r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "move.done", member.DisplayName(), view.TeamLabel(team))))
+	}
+}
+
+// teamChoiceRow lists the teams with free places except the skipped one.
+func teamChoiceRow(match *entity.Match, callback string, skip *entity.Team) []tgbotapi.InlineKeyboardButton {
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, team := range match.Teams {
+		if !team.HasRoom() || skip != nil && team.ID == skip.ID {
+			continue
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%s %d/%d", view.TeamLabel(team), len(team.Members), team.Size),
+			fmt.Sprintf("%s-%d-%d", callback, match.ID, team.ID)))
+	}
+	return row
+}
@@ -0,0 +1,152 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/view"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func (r *router) askTransfer(telegramID, matchID int64) {
+	r.userCache.SetMatchID(telegramID, matchID)
+	r.userCache.SetStatus(telegramID, users.StatusSpotTransfer)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "transfer.ask")))
+}
+
+// offerSpot sends the player named by the user an offer to take their spot.
+func (r *router) offerSpot(msg *tgbotapi.Message, user *entity.User) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	invitees := parseInvitees(msg)
+	if len(invitees) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "transfer.ask_short")))
+		return
+	}
+	transfer, receiver, err := r.service.OfferSpot(context.Background(), cached.MatchID, user.ID, invitees[0])
+	switch errors.Type(err) {
+	case errors.Invalid:
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "transfer.unknown_player")))
+		return
+	case errors.Conflict:
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "transfer.already_in")))
+		return
+	case errors.Forbidden:
+		r.userCache.SetStatus(msg.From.ID, 0)
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "transfer.not_in_match")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	match, err := r.service.GetMatchByMatchID(context.Background(), transfer.MatchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	lang := view.Lang(receiver)
+	offer := tgbotapi.NewMessage(receiver.ChatID, view.Notification(lang, i18n.T(lang, "transfer.offer", user.DisplayName()), match, receiver.ID))
+	offer.ParseMode = tgbotapi.ModeHTML
+	offer.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "transfer.accept"), fmt.Sprintf("transfer_accept-%d", transfer.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "transfer.decline"), fmt.Sprintf("transfer_decline-%d", transfer.ID)),
+		),
+	)
+	r.notifier.Notify(receiver, enum.NotificationInvitation, offer)
+	r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "transfer.offered", receiver.DisplayName())))
+}
+
+func (r *router) acceptTransfer(callback *tgbotapi.CallbackQuery, transferID int64, user *entity.User) {
+	transfer, err := r.service.AcceptSpotTransfer(context.Background(), transferID, user.ID)
+	if errors.Type(err) == errors.Forbidden {
+		r.refuseTransfer(callback.From.ID, transferID, user)
+		return
+	}
+	if errors.Type(err) == errors.Conflict {
+		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "transfer.expired")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	match, err := r.service.GetMatchByMatchID(context.Background(), transfer.MatchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "transfer.accepted", match.ID))
+	msg.ReplyMarkup = matchMoreKeyboard(r.lang(callback.From.ID), match.ID)
+	r.bot.Send(msg)
+	r.inviteToMatchChat(match, user)
+	from, err := r.service.GetUserByID(context.Background(), transfer.FromUserID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.removeFromMatchChat(match, from)
+	msg = tgbotapi.NewMessage(from.ChatID, i18n.T(view.Lang(from), "transfer.accepted_from", user.DisplayName(), match.ID))
+	msg.ReplyMarkup = matchMoreKeyboard(view.Lang(from), match.ID)
+	r.notifier.Notify(from, enum.NotificationInvitation, msg)
+	organizer, err := r.service.GetUserByID(context.Background(), match.OrganizerID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg = tgbotapi.NewMessage(organizer.ChatID, i18n.T(view.Lang(organizer), "transfer.organizer",
+		from.DisplayName(), match.ID, user.DisplayName()))
+	msg.ReplyMarkup = matchMoreKeyboard(view.Lang(organizer), match.ID)
+	r.notifier.Notify(organizer, enum.NotificationSignOut, msg)
+}
+
+// refuseTransfer tells the receiver why the match doesn't let them take the
+// spot.
+func (r *router) refuseTransfer(telegramID, transferID int64, user *entity.User) {
+	transfer, err := r.service.GetSpotTransfer(context.Background(), transferID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if transfer.ToUserID != user.ID {
+		return
+	}
+	match, err := r.service.GetMatchByMatchID(context.Background(), transfer.MatchID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.refuseSignUp(telegramID, match, user)
+}
+
+func (r *router) declineTransfer(callback *tgbotapi.CallbackQuery, transferID int64, user *entity.User) {
+	transfer, err := r.service.DeclineSpotTransfer(context.Background(), transferID, user.ID)
+	if errors.Type(err) == errors.Conflict {
+		r.bot.Send(tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "transfer.expired")))
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.bot.Send(tgbotapi.NewMessage(callback.From.ID, r.t(callback.From.ID, "transfer.declined")))
+	from, err := r.service.GetUserByID(context.Background(), transfer.FromUserID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	msg := tgbotapi.NewMessage(from.ChatID, i18n.T(view.Lang(from), "transfer.declined_from", user.DisplayName(), transfer.MatchID))
+	msg.ReplyMarkup = matchMoreKeyboard(view.Lang(from), transfer.MatchID)
+	r.notifier.Notify(from, enum.NotificationInvitation, msg)
+}
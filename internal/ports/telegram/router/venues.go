@@ -0,0 +1,298 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const nearSearchRadiusKm = 10
+
+// askVenue offers the venues already used for the sport and accepts either
+// a new venue name or a shared location.
+func (r *router) askVenue(chatID int64, sport enum.SportType) {
+	msg := tgbotapi.NewMessage(chatID, r.t(chatID, "venue.ask"))
+	venues, err := r.service.GetVenuesBySport(context.Background(), sport)
+	if err != nil {
+		log.Println(err)
+	}
+	if len(venues) != 0 {
+		msg.ReplyMarkup = venuesKeyboard(venues)
+	}
+	r.bot.Send(msg)
+}
+
+// chooseVenue handles a "venue-<id>" button pressed in the match wizard.
+func (r *router) chooseVenue(callback *tgbotapi.CallbackQuery) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(callback.Data, "venue-"), 10, 64)
+	if err != nil {
+		return
+	}
+	venue, err := r.service.GetVenue(context.Background(), id)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.cache.SetVenue(callback.From.ID, venue)
+	r.askMatchDay(callback.From.ID)
+}
+
+// setMatchVenue saves the venue typed or shared in the match wizard.
+func (r *router) setMatchVenue(msg *tgbotapi.Message, user *entity.User) {
+	match, err := r.cache.GetMatch(msg.From.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	venue := &entity.Venue{Name: msg.Text, CreatedBy: &user.ID}
+	switch {
+	case msg.Venue != nil:
+		venue.Name = msg.Venue.Title
+		venue.Address = msg.Venue.Address
+		venue.Latitude, venue.Longitude = &msg.Venue.Location.Latitude, &msg.Venue.Location.Longitude
+	case msg.Location != nil:
+		venue.Name = fmt.Sprintf("%.5f, %.5f", msg.Location.Latitude, msg.Location.Longitude)
+		venue.Latitude, venue.Longitude = &msg.Location.Latitude, &msg.Location.Longitude
+	}
+	if strings.TrimSpace(venue.Name) == "" {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "venue.ask_name")))
+		return
+	}
+	venue, err = r.service.SaveVenue(context.Background(), venue, match.Type)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	r.cache.SetVenue(msg.From.ID, venue)
+	r.askMatchDay(msg.From.ID)
+}
+
+func (r *router) askMatchDay(chatID int64) {
+	msg := tgbotapi.NewMessage(chatID, r.t(chatID, "create.ask_day"))
+	msg.ReplyMarkup = matchDayKeyboard(r.lang(chatID))
+	r.bot.Send(msg)
+}
+
+// askRent offers the venue's usual rent as a button when it is known.
+func (r *router) askRent(telegramID int64) {
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "create.ask_rent"))
+	match, err := r.cache.GetMatch(telegramID)
+	if err == nil && match.Venue != nil && match.Venue.DefaultRent > 0 {
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(r.t(telegramID, "money", match.Venue.DefaultRent),
+					fmt.Sprintf("rent-%d", match.Venue.DefaultRent)),
+			),
+		)
+	}
+	r.bot.Send(msg)
+}
+
+func (r *router) setRent(telegramID int64, rent int64) {
+	r.cache.SetRent(telegramID, rent)
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "create.ask_private"))
+	msg.ReplyMarkup = matchPrivateKeyboard(r.lang(telegramID))
+	r.bot.Send(msg)
+}
+
+// sendVenue sends the venue photo and a map pin when they are available.
+func (r *router) sendVenue(chatID int64, venue *entity.Venue) {
+	if venue == nil {
+		return
+	}
+	if venue.PhotoFileID != "" {
+		photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(venue.PhotoFileID))
+		photo.Caption = venue.Name
+		r.bot.Send(photo)
+	}
+	if venue.HasLocation() {
+		address := venue.Address
+		if address == "" {
+			address = venue.Name
+		}
+		r.bot.Send(tgbotapi.NewVenue(chatID, venue.Name, address, *venue.Latitude, *venue.Longitude))
+	}
+}
+
+// showVenues lists the venues created by the user so that coordinates and
+// photos can be attached to them.
+func (r *router) showVenues(chatID int64, user *entity.User) {
+	venues, err := r.service.GetVenuesByCreator(context.Background(), user.ID)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if len(venues) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(chatID, r.t(chatID, "venues.none")))
+		return
+	}
+	for _, venue := range venues {
+		text := "🏟 " + venue.Name
+		if venue.Address != "" {
+			text += "\n" + venue.Address
+		}
+		if venue.DefaultRent > 0 {
+			text += "\n" + r.t(chatID, "venue.rent", venue.DefaultRent)
+		}
+		location := r.t(chatID, "venue.set_location")
+		if venue.HasLocation() {
+			location = r.t(chatID, "venue.change_location")
+		}
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(location, fmt.Sprintf("venue_location-%d", venue.ID)),
+				tgbotapi.NewInlineKeyboardButtonData(r.t(chatID, "venue.photo"), fmt.Sprintf("venue_photo-%d", venue.ID)),
+			),
+			tgbotapi.NewInlineKeyboardRow(venuePolicyButton(r.lang(chatID), venue)),
+		)
+		r.bot.Send(msg)
+	}
+}
+
+// ownedVenue loads the venue and reports whether the user added it. Only
+// they may change it, venues nobody added belong to no one.
+func (r *router) ownedVenue(venueID int64, user *entity.User) (*entity.Venue, bool) {
+	venue, err := r.service.GetVenue(context.Background(), venueID)
+	if err != nil {
+		log.Println(err)
+		return nil, false
+	}
+	return venue, venue.OwnedBy(user.ID)
+}
+
+func (r *router) askVenueLocation(telegramID, venueID int64, user *entity.User) {
+	if _, ok := r.ownedVenue(venueID, user); !ok {
+		return
+	}
+	r.userCache.SetVenueID(telegramID, venueID)
+	r.userCache.SetStatus(telegramID, users.StatusVenueLocation)
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "venue.ask_location"))
+	msg.ReplyMarkup = shareLocationKeyboard(r.lang(telegramID))
+	r.bot.Send(msg)
+}
+
+func (r *router) askVenuePhoto(telegramID, venueID int64, user *entity.User) {
+	if _, ok := r.ownedVenue(venueID, user); !ok {
+		return
+	}
+	r.userCache.SetVenueID(telegramID, venueID)
+	r.userCache.SetStatus(telegramID, users.StatusVenuePhoto)
+	r.bot.Send(tgbotapi.NewMessage(telegramID, r.t(telegramID, "venue.ask_photo")))
+}
+
+func (r *router) setVenueLocation(msg *tgbotapi.Message, user *entity.User) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	if _, ok := r.ownedVenue(cached.VenueID, user); !ok {
+		r.userCache.SetStatus(msg.From.ID, 0)
+		return
+	}
+	point, address, ok := sharedLocation(msg)
+	if !ok {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "location.ask_attach")))
+		return
+	}
+	if err := r.service.SetVenueLocation(context.Background(), cached.VenueID, point, address); err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	reply := tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "venue.location_saved"))
+	reply.ReplyMarkup = tgbotapi.NewRemoveKeyboard(false)
+	r.bot.Send(reply)
+}
+
+func (r *router) setVenuePhoto(msg *tgbotapi.Message, user *entity.User) {
+	cached, ok := r.userCache.GetUser(msg.From.ID)
+	if !ok {
+		log.Println("user not found in cache")
+		return
+	}
+	if _, ok := r.ownedVenue(cached.VenueID, user); !ok {
+		r.userCache.SetStatus(msg.From.ID, 0)
+		return
+	}
+	if len(msg.Photo) == 0 {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "venue.ask_photo")))
+		return
+	}
+	// the last size is the largest one
+	fileID := msg.Photo[len(msg.Photo)-1].FileID
+	if err := r.service.SetVenuePhoto(context.Background(), cached.VenueID, fileID); err != nil {
+		log.Println(err)
+		return
+	}
+	r.userCache.SetStatus(msg.From.ID, 0)
+	r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "venue.photo_saved")))
+}
+
+func (r *router) askNearLocation(telegramID int64) {
+	r.userCache.SetStatus(telegramID, users.StatusFilterNear)
+	msg := tgbotapi.NewMessage(telegramID, r.t(telegramID, "search.ask_near"))
+	msg.ReplyMarkup = shareLocationKeyboard(r.lang(telegramID))
+	r.bot.Send(msg)
+}
+
+// setNearFilter searches for matches around the shared location.
+func (r *router) setNearFilter(msg *tgbotapi.Message) {
+	point, _, ok := sharedLocation(msg)
+	if !ok {
+		r.bot.Send(tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "location.ask_attach")))
+		return
+	}
+	search := r.userCache.GetSearch(msg.From.ID)
+	search.Filter.Near = &point
+	search.Filter.RadiusKm = nearSearchRadiusKm
+	search.Filter.Offset = 0
+	r.userCache.SetSearch(msg.From.ID, search)
+	r.userCache.SetStatus(msg.From.ID, 0)
+	reply := tgbotapi.NewMessage(msg.From.ID, r.t(msg.From.ID, "search.near"))
+	reply.ReplyMarkup = tgbotapi.NewRemoveKeyboard(false)
+	r.bot.Send(reply)
+	r.showMatches(msg.From.ID)
+}
+
+func sharedLocation(msg *tgbotapi.Message) (entity.GeoPoint, string, bool) {
+	switch {
+	case msg.Venue != nil:
+		return entity.GeoPoint{Latitude: msg.Venue.Location.Latitude, Longitude: msg.Venue.Location.Longitude}, msg.Venue.Address, true
+	case msg.Location != nil:
+		return entity.GeoPoint{Latitude: msg.Location.Latitude, Longitude: msg.Location.Longitude}, "", true
+	default:
+		return entity.GeoPoint{}, "", false
+	}
+}
+
+func venuesKeyboard(venues []*entity.Venue) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for _, venue := range venues {
+		label := venue.Name
+		if venue.HasLocation() {
+			label = "📍 " + label
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("venue-%d", venue.ID)),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func shareLocationKeyboard(lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	return tgbotapi.NewOneTimeReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(
+			tgbotapi.NewKeyboardButtonLocation(i18n.T(lang, "location.share")),
+		),
+	)
+}
@@ -1,36 +1,139 @@
 package telegram
 
 import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/matches"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/cache/users"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/config"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/notifier"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/outbox"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/ports/telegram/router"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/service/match"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+const (
+	resultsCheckInterval       = time.Minute
+	notificationsFlushInterval = time.Minute
+	deliveryRetryInterval      = time.Minute
+	pollTimeout                = 60
+)
+
 type Server struct {
 	bot          *tgbotapi.BotAPI
 	matchesCache matches.Cache
 	usersCache   users.Cache
 	matchService match.Service
+	adminIDs     []int64
+	webhook      config.Webhook
 }
 
-func New(token string, matchesCache matches.Cache, userCache users.Cache, matchService match.Service) (*Server, error) {
+func New(token string, matchesCache matches.Cache, userCache users.Cache, matchService match.Service, adminIDs []int64, webhook config.Webhook) (*Server, error) {
 	bot, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Server{bot: bot, matchesCache: matchesCache, matchService: matchService, usersCache: userCache}, nil
+	return &Server{bot: bot, matchesCache: matchesCache, matchService: matchService, usersCache: userCache, adminIDs: adminIDs, webhook: webhook}, nil
+}
+
+// Start receives updates through the webhook when one is configured and
+// by long polling otherwise, until the process is interrupted. It returns
+// once the updates being handled, the periodic jobs running and the
+// notifications being sent are done.
+func (s *Server) Start() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	bot := outbox.New(s.bot, s.matchService)
+	notifications := notifier.New(bot, s.matchService)
+	routerHandler := router.NewRouter(bot, s.matchesCache, s.usersCache, s.matchService, notifications, s.adminIDs)
+	var jobs sync.WaitGroup
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		bot.Run(ctx)
+	}()
+	for _, job := range []struct {
+		interval time.Duration
+		run      func()
+	}{
+		{resultsCheckInterval, routerHandler.RequestResults},
+		{notificationsFlushInterval, notifications.Flush},
+		{deliveryRetryInterval, bot.Retry},
+	} {
+		job := job
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			every(ctx, job.interval, job.run)
+		}()
+	}
+
+	var handling sync.WaitGroup
+	handle := func(update tgbotapi.Update) {
+		handling.Add(1)
+		go func() {
+			defer handling.Done()
+			routerHandler.HandleUpdate(update)
+		}()
+	}
+	var err error
+	if s.webhook.URL != "" {
+		log.Println("receiving updates through the webhook")
+		err = s.serveWebhook(ctx, handle)
+	} else {
+		log.Println("receiving updates by long polling")
+		err = s.poll(ctx, handle)
+	}
+	handling.Wait()
+	jobs.Wait()
+	return err
 }
 
-func (s *Server) Start() {
-	u := tgbotapi.UpdateConfig{
-		Timeout: 60,
+// every runs job at each interval until ctx is done.
+func every(ctx context.Context, interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job()
+		}
 	}
-	routerHandler := router.NewRouter(s.bot, s.matchesCache, s.usersCache, s.matchService)
+}
 
-	for update := range s.bot.GetUpdatesChan(u) {
-		go routerHandler.HandleUpdate(update)
+// poll receives updates by long polling until ctx is done. Telegram doesn't
+// answer getUpdates while a webhook is set, so it is deleted first, keeping
+// the updates it holds for the bot.
+func (s *Server) poll(ctx context.Context, handle func(tgbotapi.Update)) error {
+	if _, err := s.bot.Request(tgbotapi.DeleteWebhookConfig{}); err != nil {
+		return err
+	}
+	updates := s.bot.GetUpdatesChan(tgbotapi.UpdateConfig{Timeout: pollTimeout})
+	go func() {
+		<-ctx.Done()
+		s.bot.StopReceivingUpdates()
+	}()
+	last := 0
+	for update := range updates {
+		last = update.UpdateID
+		handle(update)
+	}
+	if last == 0 {
+		return nil
 	}
+	// Telegram forgets updates only when asked for the next ones, confirm
+	// the handled ones so that the next start doesn't get them again,
+	// whichever way it receives them
+	_, err := s.bot.GetUpdates(tgbotapi.UpdateConfig{Offset: last + 1, Limit: 1})
+	return err
 }
@@ -0,0 +1,240 @@
+// Package view renders the messages the bot shows matches in. Templates
+// are HTML so that names, locations and other user input are escaped;
+// messages holding rendered text have to be sent with tgbotapi.ModeHTML.
+package view
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/service/match"
+)
+
+//go:embed templates/*.tmpl
+var files embed.FS
+
+// templates is never executed itself, every render clones it with the
+// functions bound to the language of the reader.
+var templates = template.Must(template.New("").Funcs(funcs(i18n.Default)).ParseFS(files, "templates/*.tmpl"))
+
+type cardData struct {
+	Headline string
+	Match    *entity.Match
+	// Viewer is the user the card is shown to, their discount is added
+	// to the card.
+	Viewer int64
+}
+
+type rosterData struct {
+	Team *entity.Team
+	Size int64
+}
+
+// Card is the full match card with its teams as seen by the viewer.
+func Card(lang i18n.Lang, m *entity.Match, viewerID int64) string {
+	return render(lang, "card", cardData{Match: m, Viewer: viewerID})
+}
+
+// Listing is the one line a match takes in search results and lists.
+func Listing(lang i18n.Lang, m *entity.Match) string {
+	return render(lang, "listing", m)
+}
+
+// Roster is the team with its players and their payment status.
+func Roster(lang i18n.Lang, team *entity.Team, size int64) string {
+	return render(lang, "roster", rosterData{Team: team, Size: size})
+}
+
+// Notification is the headline followed by the card of the match it is
+// about.
+func Notification(lang i18n.Lang, headline string, m *entity.Match, viewerID int64) string {
+	return render(lang, "notification", cardData{Headline: headline, Match: m, Viewer: viewerID})
+}
+
+// Price is the price of the match as shown to players.
+func Price(lang i18n.Lang, m *entity.Match) string {
+	if m.PricingModel == enum.PricingModelFree {
+		return i18n.T(lang, "price.free")
+	}
+	return i18n.T(lang, "price.per_player", m.BasePrice())
+}
+
+// Lang is the language messages to the user are sent in.
+func Lang(u *entity.User) i18n.Lang {
+	return i18n.Parse(u.Language)
+}
+
+// SportName is the name of the sport in the language, falling back to the
+// default language and then to the code.
+func SportName(lang i18n.Lang, s *entity.Sport) string {
+	if name, ok := s.Names[string(lang)]; ok && name != "" {
+		return name
+	}
+	if name, ok := s.Names[string(i18n.Default)]; ok && name != "" {
+		return name
+	}
+	return string(s.Code)
+}
+
+// SportLabel is the emoji of the sport followed by its name.
+func SportLabel(lang i18n.Lang, s *entity.Sport) string {
+	if s.Emoji == "" {
+		return SportName(lang, s)
+	}
+	return s.Emoji + " " + SportName(lang, s)
+}
+
+// TeamPalette is the set of badges teams are told apart by. New matches
+// take them in this order.
+var TeamPalette = []string{
+	"🟥", "🟦", "🟩", "🟨", "🟪", "⬛️", "🟫", "🟧", "⬜️",
+	"🔴", "🔵", "🟢", "🟡", "🟣", "🟠", "⚫️", "🟤",
+	"🦁", "🐺", "🦅", "🐻", "🦈", "🐉", "🐝", "⚡️", "🔥", "⭐️", "🚀",
+}
+
+// Badge is the emoji the team is shown with.
+func Badge(t *entity.Team) string {
+	if t.Emoji == "" {
+		return "⚪️"
+	}
+	return t.Emoji
+}
+
+// TeamLabel is the badge followed by the team name when the team has one.
+func TeamLabel(t *entity.Team) string {
+	if t.Name == "" {
+		return Badge(t)
+	}
+	return Badge(t) + " " + t.Name
+}
+
+// Score formats the recorded score as "🟥 3 : 2 🟦".
+func Score(m *entity.Match) string {
+	out := ""
+	for i, t := range m.Teams {
+		var score int64
+		if t.Score != nil {
+			score = *t.Score
+		}
+		switch {
+		case i == 0:
+			out += fmt.Sprintf("%s %d", TeamLabel(t), score)
+		case i == 1 && len(m.Teams) == 2:
+			out += fmt.Sprintf(" : %d %s", score, TeamLabel(t))
+		default:
+			out += fmt.Sprintf(", %s %d", TeamLabel(t), score)
+		}
+	}
+	return out
+}
+
+// Levels are the rating presets players and eligibility rules pick from,
+// the lowest first.
+var Levels = []struct {
+	Key    string
+	Rating int64
+}{
+	{"rating.beginner", match.RatingBeginner},
+	{"rating.intermediate", match.RatingIntermediate},
+	{"rating.advanced", match.RatingAdvanced},
+	{"rating.pro", match.RatingPro},
+}
+
+// Level is the name of the highest preset the rating reaches.
+func Level(lang i18n.Lang, rating int64) string {
+	key := Levels[0].Key
+	for _, level := range Levels {
+		if rating >= level.Rating {
+			key = level.Key
+		}
+	}
+	return i18n.T(lang, key)
+}
+
+// Rules lists the eligibility rules of the match, empty when there are none.
+func Rules(lang i18n.Lang, e *entity.Eligibility) string {
+	var parts []string
+	switch {
+	case e.MinLevel != nil && e.MaxLevel != nil:
+		parts = append(parts, i18n.T(lang, "eligibility.level_range", Level(lang, *e.MinLevel), Level(lang, *e.MaxLevel)))
+	case e.MinLevel != nil:
+		parts = append(parts, i18n.T(lang, "eligibility.level_min", Level(lang, *e.MinLevel)))
+	case e.MaxLevel != nil:
+		parts = append(parts, i18n.T(lang, "eligibility.level_max", Level(lang, *e.MaxLevel)))
+	}
+	if e.Gender != enum.GenderAny {
+		parts = append(parts, i18n.T(lang, "gender."+string(e.Gender)))
+	}
+	switch {
+	case e.MinAge != nil && e.MaxAge != nil:
+		parts = append(parts, i18n.T(lang, "eligibility.age_range", *e.MinAge, *e.MaxAge))
+	case e.MinAge != nil:
+		parts = append(parts, i18n.T(lang, "eligibility.age_min", *e.MinAge))
+	case e.MaxAge != nil:
+		parts = append(parts, i18n.T(lang, "eligibility.age_max", *e.MaxAge))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func render(lang i18n.Lang, name string, data any) string {
+	t, err := templates.Clone()
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+	var out strings.Builder
+	if err := t.Funcs(funcs(lang)).ExecuteTemplate(&out, name, data); err != nil {
+		log.Println(err)
+		return ""
+	}
+	return out.String()
+}
+
+func funcs(lang i18n.Lang) template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string, args ...any) string {
+			return i18n.T(lang, key, args...)
+		},
+		"tn": func(key string, n int64) string {
+			return i18n.N(lang, key, n, n)
+		},
+		"sport": func(m *entity.Match) string {
+			if m.Sport == nil {
+				return string(m.Type)
+			}
+			return SportName(lang, m.Sport)
+		},
+		"label": TeamLabel,
+		"score": Score,
+		"price": func(m *entity.Match) string {
+			return Price(lang, m)
+		},
+		"duration": func(m *entity.Match) string {
+			return i18n.T(lang, "duration.hours", m.FinishAt.Sub(m.StartAt).Minutes()/60.0)
+		},
+		"date": func(t time.Time) string {
+			return fmt.Sprintf("%d/%d", t.Day(), t.Month())
+		},
+		"roster": func(team *entity.Team, size int64) rosterData {
+			return rosterData{Team: team, Size: size}
+		},
+		// free is the number of places left in a match loaded without its
+		// teams, as in search results.
+		"free": func(m *entity.Match) int64 {
+			return m.TeamCount*m.TeamSize - m.MembersCount
+		},
+		"rules": func(m *entity.Match) string {
+			return Rules(lang, &m.Eligibility)
+		},
+		"distance": func(m *entity.Match) float64 {
+			return *m.Distance
+		},
+	}
+}
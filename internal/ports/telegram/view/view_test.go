@@ -0,0 +1,97 @@
+package view
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// testMatch is a match with a score, a discount and user input that has
+// to be escaped: a location and names with "<", "&" and quotes.
+func testMatch() *entity.Match {
+	yes := true
+	three, two := int64(3), int64(2)
+	distance := 2.345
+	return &entity.Match{
+		ID:            42,
+		Type:          enum.SportType("football"),
+		OrganizerName: `@organizer`,
+		Location:      `Arena <"Central"> & Co's pitch`,
+		Rent:          20000,
+		StartAt:       time.Date(2023, time.July, 9, 19, 0, 0, 0, time.UTC),
+		FinishAt:      time.Date(2023, time.July, 9, 20, 30, 0, 0, time.UTC),
+		TeamSize:      3,
+		TeamCount:     2,
+		MembersCount:  3,
+		Distance:      &distance,
+		PricingModel:  enum.PricingModelCapacity,
+		Discounts:     map[int64]int64{1: 50},
+		Sport: &entity.Sport{
+			Code:  "football",
+			Emoji: "⚽️",
+			Names: map[string]string{"ru": "Футбол", "en": "Football"},
+		},
+		Teams: []*entity.Team{
+			{ID: 1, Name: `<Lions> & "Co"`, Emoji: "🦁", Size: 3, Score: &three, Members: []*entity.User{
+				{ID: 1, Name: `Tom <b>"Bomber"</b> & Jerry`, Paid: true, Confirmed: true, Goals: 2, MVP: true, Attended: &yes},
+				{ID: 2, Username: "o'neil", Confirmed: true},
+			}},
+			{ID: 2, Emoji: "🟦", Size: 3, Score: &two, Members: []*entity.User{
+				{ID: 3, Name: "Ann"},
+			}},
+		},
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	m := testMatch()
+	tests := []struct {
+		name string
+		got  string
+	}{
+		{"card_en", Card(i18n.English, m, 1)},
+		{"card_ru", Card(i18n.Russian, m, 3)},
+		{"listing_en", Listing(i18n.English, m)},
+		{"roster_en", Roster(i18n.English, m.Teams[0], m.TeamSize)},
+		{"notification_en", Notification(i18n.English, `Match <#42> & "friends" moved`, m, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got == "" {
+				t.Fatal("nothing rendered")
+			}
+			path := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, []byte(tt.got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.got != string(want) {
+				t.Errorf("rendered:\n%s\nwant:\n%s", tt.got, want)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	m := testMatch()
+	if got, want := Score(m), `🦁 <Lions> & "Co" 3 : 2 🟦`; got != want {
+		t.Errorf("Score() = %q, want %q", got, want)
+	}
+	m.Teams = append(m.Teams, &entity.Team{ID: 3})
+	if got, want := Score(m), `🦁 <Lions> & "Co" 3, 🟦 2, ⚪️ 0`; got != want {
+		t.Errorf("Score() of three teams = %q, want %q", got, want)
+	}
+}
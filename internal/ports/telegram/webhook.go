@@ -0,0 +1,115 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	// secretHeader carries the secret token the webhook was set with.
+	secretHeader         = "X-Telegram-Bot-Api-Secret-Token"
+	defaultWebhookListen = ":8080"
+	// maxUpdateSize is far above any update Telegram sends, it only keeps
+	// a stranger from making the bot read an endless body.
+	maxUpdateSize     = 1 << 20
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// secretPattern is what Telegram accepts as a secret token.
+var secretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// NewWebhookHandler returns the handler Telegram posts updates to. It
+// refuses requests without the secret token and passes each update to
+// handle, which must not block, answering right away so that Telegram
+// doesn't hold back the next updates.
+func NewWebhookHandler(secret string, handle func(tgbotapi.Update)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(secretHeader)), []byte(secret)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		var update tgbotapi.Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUpdateSize)).Decode(&update); err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		handle(update)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// serveWebhook sets the webhook and serves it until ctx is done. The
+// webhook is left set when the bot stops, so that Telegram keeps the
+// updates until it is back.
+func (s *Server) serveWebhook(ctx context.Context, handle func(tgbotapi.Update)) error {
+	if !secretPattern.MatchString(s.webhook.Secret) {
+		return errors.New("webhook secret must be 1-256 letters, digits, \"_\" or \"-\"")
+	}
+	if (s.webhook.CertFile == "") != (s.webhook.KeyFile == "") {
+		return errors.New("webhook needs both a certificate and a key to serve TLS")
+	}
+	u, err := url.Parse(s.webhook.URL)
+	if err != nil {
+		return err
+	}
+	if err := s.setWebhook(); err != nil {
+		return err
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, NewWebhookHandler(s.webhook.Secret, handle))
+	listen := s.webhook.Listen
+	if listen == "" {
+		listen = defaultWebhookListen
+	}
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+	errs := make(chan error, 1)
+	go func() {
+		if s.webhook.CertFile != "" {
+			errs <- server.ListenAndServeTLS(s.webhook.CertFile, s.webhook.KeyFile)
+		} else {
+			// plain HTTP, TLS is up to the reverse proxy
+			errs <- server.ListenAndServe()
+		}
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// setWebhook points Telegram to the webhook. The updates it holds for the
+// bot are kept, so none is lost when switching from polling. The client
+// doesn't know the secret token, so the request is made by hand.
+func (s *Server) setWebhook() error {
+	params := tgbotapi.Params{"url": s.webhook.URL, "secret_token": s.webhook.Secret}
+	if s.webhook.SelfSigned {
+		_, err := s.bot.UploadFiles("setWebhook", params, []tgbotapi.RequestFile{
+			{Name: "certificate", Data: tgbotapi.FilePath(s.webhook.CertFile)},
+		})
+		return err
+	}
+	_, err := s.bot.MakeRequest("setWebhook", params)
+	return err
+}
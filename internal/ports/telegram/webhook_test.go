@@ -0,0 +1,63 @@
+package telegram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	const secret = "s3cret_token-1"
+	update := `{"update_id": 42, "message": {"message_id": 7, "chat": {"id": 100, "type": "private"}, "text": "/start"}}`
+	tests := []struct {
+		name    string
+		method  string
+		secret  string
+		body    string
+		status  int
+		handled bool
+	}{
+		{name: "update", method: http.MethodPost, secret: secret, body: update, status: http.StatusOK, handled: true},
+		{name: "no secret", method: http.MethodPost, body: update, status: http.StatusUnauthorized},
+		{name: "wrong secret", method: http.MethodPost, secret: "s3cret_token-2", body: update, status: http.StatusUnauthorized},
+		{name: "secret prefix", method: http.MethodPost, secret: "s3cret", body: update, status: http.StatusUnauthorized},
+		{name: "GET", method: http.MethodGet, secret: secret, status: http.StatusMethodNotAllowed},
+		{name: "malformed body", method: http.MethodPost, secret: secret, body: `{"update_id": `, status: http.StatusBadRequest},
+		{name: "empty body", method: http.MethodPost, secret: secret, status: http.StatusBadRequest},
+		{name: "oversized body", method: http.MethodPost, secret: secret,
+			body: `{"update_id": 42, "message": {"text": "` + strings.Repeat("a", maxUpdateSize) + `"}}`, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled []tgbotapi.Update
+			handler := NewWebhookHandler(secret, func(u tgbotapi.Update) { handled = append(handled, u) })
+			req := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+			if tt.secret != "" {
+				req.Header.Set(secretHeader, tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != http.MethodPost {
+				t.Errorf("Allow = %q, want %q", rec.Header().Get("Allow"), http.MethodPost)
+			}
+			if !tt.handled {
+				if len(handled) != 0 {
+					t.Errorf("%d updates handled, want none", len(handled))
+				}
+				return
+			}
+			if len(handled) != 1 {
+				t.Fatalf("%d updates handled, want 1", len(handled))
+			}
+			if u := handled[0]; u.UpdateID != 42 || u.Message == nil || u.Message.Text != "/start" || u.Message.Chat.ID != 100 {
+				t.Errorf("handled update %+v, want update 42 with /start from chat 100", u)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	setAttendanceStmt      = `UPDATE team_members SET attended=$1 WHERE member_id=$2 AND team_id=$3;`
+	recordCancellationStmt = `INSERT INTO cancellations(match_id, user_id, late, liable_amount, replaced_by)
+								VALUES($1, $2, $3, $4, $5);`
+	setCancelWindowStmt   = `UPDATE matches SET cancel_window_hours = $2 WHERE id = $1;`
+	setMinReliabilityStmt = `UPDATE matches SET min_reliability = $2 WHERE id = $1;`
+	getReliabilityStmt    = `SELECT
+								COUNT(*) FILTER (WHERE tm.attended = true) AS attended,
+								COUNT(*) FILTER (WHERE tm.attended = false AND tm.confirmed = true) AS no_shows,
+								(SELECT COUNT(*) FROM cancellations c WHERE c.user_id = $1 AND c.late = true) AS late_cancels
+								FROM team_members tm
+								JOIN teams t ON t.id = tm.team_id
+								JOIN matches m ON m.id = t.match_id
+								WHERE tm.member_id = $1 AND m.cancelled = false;`
+)
+
+func (r *repository) SetAttendance(ctx context.Context, matchID, userID int64, attended bool) error {
+	teamID, err := r.GetTeamIDByMatchAndUser(ctx, matchID, userID)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, setAttendanceStmt, attended, userID, teamID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) RecordCancellation(ctx context.Context, c *entity.Cancellation) error {
+	_, err := r.pool.Exec(ctx, recordCancellationStmt, c.MatchID, c.UserID, c.Late, c.LiableAmount, c.ReplacedBy)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) SetCancelWindow(ctx context.Context, matchID, hours int64) error {
+	_, err := r.pool.Exec(ctx, setCancelWindowStmt, matchID, hours)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetReliability(ctx context.Context, userID int64) (*entity.Reliability, error) {
+	var reliability entity.Reliability
+	if err := pgxscan.Get(ctx, r.pool, &reliability, getReliabilityStmt, userID); err != nil {
+		return nil, err
+	}
+	return &reliability, nil
+}
+
+func (r *repository) SetMinReliability(ctx context.Context, matchID int64, minReliability *int64) error {
+	_, err := r.pool.Exec(ctx, setMinReliabilityStmt, matchID, minReliability)
+	if err != nil {
+		return err
+	}
+	return nil
+}
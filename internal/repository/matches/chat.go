@@ -0,0 +1,90 @@
+package matches
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	createChatMessageStmt = `INSERT INTO match_messages(match_id, sender_id, reply_to_id, text) VALUES($1, $2, $3, $4)
+							RETURNING id, created_at;`
+	countChatMessagesStmt    = `SELECT COUNT(*) FROM match_messages WHERE sender_id = $1 AND created_at > $2;`
+	addChatCopyStmt          = `INSERT INTO match_message_copies(message_id, chat_id, telegram_message_id) VALUES($1, $2, $3);`
+	getChatCopiesStmt        = `SELECT message_id, chat_id, telegram_message_id FROM match_message_copies WHERE message_id = $1;`
+	getChatMessageByCopyStmt = `SELECT m.id, m.match_id, m.sender_id, m.reply_to_id, m.text, m.created_at FROM match_messages m
+							JOIN match_message_copies c ON c.message_id = m.id
+							WHERE c.chat_id = $1 AND c.telegram_message_id = $2;`
+	muteChatStmt          = `INSERT INTO match_chat_mutes(match_id, user_id) VALUES($1, $2) ON CONFLICT DO NOTHING;`
+	unmuteChatStmt        = `DELETE FROM match_chat_mutes WHERE match_id = $1 AND user_id = $2;`
+	getChatMutedUsersStmt = `SELECT user_id FROM match_chat_mutes WHERE match_id = $1;`
+)
+
+func (r *repository) CreateChatMessage(ctx context.Context, message *entity.ChatMessage) error {
+	if err := r.pool.QueryRow(ctx, createChatMessageStmt, message.MatchID, message.SenderID, message.ReplyToID, message.Text).
+		Scan(&message.ID, &message.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountChatMessages counts the messages the user sent to any match since
+// the moment.
+func (r *repository) CountChatMessages(ctx context.Context, senderID int64, since time.Time) (int64, error) {
+	var count int64
+	if err := r.pool.QueryRow(ctx, countChatMessagesStmt, senderID, since).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *repository) AddChatCopy(ctx context.Context, c *entity.ChatCopy) error {
+	_, err := r.pool.Exec(ctx, addChatCopyStmt, c.MessageID, c.ChatID, c.TelegramMessageID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetChatCopies(ctx context.Context, messageID int64) ([]*entity.ChatCopy, error) {
+	var copies []*entity.ChatCopy
+	if err := pgxscan.Select(ctx, r.pool, &copies, getChatCopiesStmt, messageID); err != nil {
+		return nil, err
+	}
+	return copies, nil
+}
+
+// GetChatMessageByCopy finds the chat message delivered as the Telegram
+// message, nil when the message didn't come from a match chat.
+func (r *repository) GetChatMessageByCopy(ctx context.Context, chatID int64, telegramMessageID int) (*entity.ChatMessage, error) {
+	var message entity.ChatMessage
+	if err := pgxscan.Get(ctx, r.pool, &message, getChatMessageByCopyStmt, chatID, telegramMessageID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *repository) SetChatMuted(ctx context.Context, matchID, userID int64, muted bool) error {
+	stmt := unmuteChatStmt
+	if muted {
+		stmt = muteChatStmt
+	}
+	_, err := r.pool.Exec(ctx, stmt, matchID, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetChatMutedUsers(ctx context.Context, matchID int64) ([]int64, error) {
+	var userIDs []int64
+	if err := pgxscan.Select(ctx, r.pool, &userIDs, getChatMutedUsersStmt, matchID); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
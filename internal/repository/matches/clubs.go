@@ -0,0 +1,59 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	// an existing club keeps its emoji
+	upsertClubStmt = `INSERT INTO clubs(owner_id, name, emoji) VALUES($1, $2, $3)
+						ON CONFLICT (owner_id, lower(name)) DO UPDATE SET name = EXCLUDED.name
+						RETURNING id, owner_id, name, emoji;`
+	getClubStmt         = `SELECT id, owner_id, name, emoji FROM clubs WHERE id = $1;`
+	getClubsByOwnerStmt = `SELECT id, owner_id, name, emoji FROM clubs WHERE owner_id = $1 ORDER BY lower(name);`
+	setClubEmojiStmt    = `UPDATE clubs SET emoji = $2 WHERE id = $1;`
+	updateTeamStmt      = `UPDATE teams SET name = $2, emoji = $3, club_id = $4 WHERE id = $1;`
+)
+
+func (r *repository) UpsertClub(ctx context.Context, club *entity.Club) (*entity.Club, error) {
+	var saved entity.Club
+	if err := pgxscan.Get(ctx, r.pool, &saved, upsertClubStmt, club.OwnerID, club.Name, club.Emoji); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *repository) GetClub(ctx context.Context, id int64) (*entity.Club, error) {
+	var club entity.Club
+	if err := pgxscan.Get(ctx, r.pool, &club, getClubStmt, id); err != nil {
+		return nil, err
+	}
+	return &club, nil
+}
+
+func (r *repository) GetClubsByOwner(ctx context.Context, ownerID int64) ([]*entity.Club, error) {
+	var clubs []*entity.Club
+	if err := pgxscan.Select(ctx, r.pool, &clubs, getClubsByOwnerStmt, ownerID); err != nil {
+		return nil, err
+	}
+	return clubs, nil
+}
+
+func (r *repository) SetClubEmoji(ctx context.Context, id int64, emoji string) error {
+	_, err := r.pool.Exec(ctx, setClubEmojiStmt, id, emoji)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) UpdateTeam(ctx context.Context, team *entity.Team) error {
+	_, err := r.pool.Exec(ctx, updateTeamStmt, team.ID, team.Name, team.Emoji, team.ClubID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
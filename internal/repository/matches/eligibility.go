@@ -0,0 +1,52 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	setEligibilityStmt = `UPDATE matches SET min_level = $2, max_level = $3, gender = $4, min_age = $5, max_age = $6
+							WHERE id = $1;`
+	getProfileStmt = `SELECT u.gender, u.birth_year, COALESCE(pr.rating, 1000) AS level FROM users u
+							LEFT JOIN player_ratings pr ON pr.user_id = u.id AND pr.sport = $2
+							WHERE u.id = $1;`
+	setGenderStmt    = `UPDATE users SET gender = $2 WHERE id = $1;`
+	setBirthYearStmt = `UPDATE users SET birth_year = $2 WHERE id = $1;`
+)
+
+func (r *repository) SetEligibility(ctx context.Context, matchID int64, e *entity.Eligibility) error {
+	_, err := r.pool.Exec(ctx, setEligibilityStmt, matchID, e.MinLevel, e.MaxLevel, e.Gender, e.MinAge, e.MaxAge)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetProfile returns the profile of the user with their level in the sport.
+func (r *repository) GetProfile(ctx context.Context, userID int64, sport enum.SportType) (*entity.Profile, error) {
+	profile := &entity.Profile{}
+	if err := pgxscan.Get(ctx, r.pool, profile, getProfileStmt, userID, sport); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (r *repository) SetGender(ctx context.Context, userID int64, gender enum.Gender) error {
+	_, err := r.pool.Exec(ctx, setGenderStmt, userID, gender)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) SetBirthYear(ctx context.Context, userID, year int64) error {
+	_, err := r.pool.Exec(ctx, setBirthYearStmt, userID, year)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	addExpenseStmt     = `INSERT INTO match_expenses(match_id, category, amount) VALUES($1, $2, $3);`
+	getExpensesStmt    = `SELECT id, match_id, category, amount FROM match_expenses WHERE match_id = $1 ORDER BY id;`
+	deleteExpensesStmt = `DELETE FROM match_expenses WHERE match_id = $1;`
+)
+
+func (r *repository) AddExpense(ctx context.Context, expense *entity.Expense) error {
+	_, err := r.pool.Exec(ctx, addExpenseStmt, expense.MatchID, expense.Category, expense.Amount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetExpenses(ctx context.Context, matchID int64) ([]*entity.Expense, error) {
+	var expenses []*entity.Expense
+	if err := pgxscan.Select(ctx, r.pool, &expenses, getExpensesStmt, matchID); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (r *repository) DeleteExpenses(ctx context.Context, matchID int64) error {
+	_, err := r.pool.Exec(ctx, deleteExpensesStmt, matchID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	linkMatchChatStmt = `INSERT INTO match_chats(match_id, chat_id, title) VALUES($1, $2, $3)
+							ON CONFLICT (match_id) DO UPDATE SET chat_id = EXCLUDED.chat_id, title = EXCLUDED.title, linked_at = NOW();`
+	unlinkMatchChatStmt = `DELETE FROM match_chats WHERE match_id = $1;`
+	getMatchChatStmt    = `SELECT match_id, chat_id, title, linked_at FROM match_chats WHERE match_id = $1;`
+	saveChatInviteStmt  = `INSERT INTO match_chat_invites(match_id, user_id, invite_link) VALUES($1, $2, $3)
+							ON CONFLICT (match_id, user_id) DO UPDATE SET invite_link = EXCLUDED.invite_link;`
+	getChatInviteStmt    = `SELECT match_id, user_id, invite_link FROM match_chat_invites WHERE match_id = $1 AND user_id = $2;`
+	getChatInvitesStmt   = `SELECT match_id, user_id, invite_link FROM match_chat_invites WHERE match_id = $1;`
+	deleteChatInviteStmt = `DELETE FROM match_chat_invites WHERE match_id = $1 AND user_id = $2;`
+	// the player keeps the group while they play in or organize another
+	// upcoming match linked to it
+	countLinkedMatchesStmt = `SELECT COUNT(*) FROM match_chats mc
+							JOIN matches m ON m.id = mc.match_id
+							WHERE mc.chat_id = $1 AND mc.match_id <> $3 AND m.cancelled = false AND m.finish_at > NOW()
+							AND (m.organizer_id = $2 OR EXISTS (
+								SELECT 1 FROM teams t JOIN team_members tm ON tm.team_id = t.id
+								WHERE t.match_id = m.id AND tm.member_id = $2 AND tm.cancelled = false));`
+)
+
+func (r *repository) LinkMatchChat(ctx context.Context, chat *entity.MatchChat) error {
+	_, err := r.pool.Exec(ctx, linkMatchChatStmt, chat.MatchID, chat.ChatID, chat.Title)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) UnlinkMatchChat(ctx context.Context, matchID int64) error {
+	_, err := r.pool.Exec(ctx, unlinkMatchChatStmt, matchID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMatchChat returns the group linked to the match, nil when there is
+// none.
+func (r *repository) GetMatchChat(ctx context.Context, matchID int64) (*entity.MatchChat, error) {
+	var chat entity.MatchChat
+	if err := pgxscan.Get(ctx, r.pool, &chat, getMatchChatStmt, matchID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &chat, nil
+}
+
+func (r *repository) SaveChatInvite(ctx context.Context, invite *entity.ChatInvite) error {
+	_, err := r.pool.Exec(ctx, saveChatInviteStmt, invite.MatchID, invite.UserID, invite.InviteLink)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetChatInvite returns the link the player got for the match, nil when
+// they got none.
+func (r *repository) GetChatInvite(ctx context.Context, matchID, userID int64) (*entity.ChatInvite, error) {
+	var invite entity.ChatInvite
+	if err := pgxscan.Get(ctx, r.pool, &invite, getChatInviteStmt, matchID, userID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (r *repository) GetChatInvites(ctx context.Context, matchID int64) ([]*entity.ChatInvite, error) {
+	var invites []*entity.ChatInvite
+	if err := pgxscan.Select(ctx, r.pool, &invites, getChatInvitesStmt, matchID); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (r *repository) DeleteChatInvite(ctx context.Context, matchID, userID int64) error {
+	_, err := r.pool.Exec(ctx, deleteChatInviteStmt, matchID, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountLinkedMatches counts the other upcoming matches linked to the group
+// the user plays in or organizes.
+func (r *repository) CountLinkedMatches(ctx context.Context, chatID, userID, exceptMatchID int64) (int64, error) {
+	var count int64
+	if err := r.pool.QueryRow(ctx, countLinkedMatchesStmt, chatID, userID, exceptMatchID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
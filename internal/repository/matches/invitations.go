@@ -0,0 +1,76 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	createInvitationStmt = `INSERT INTO invitations(team_id, username, invited_by) VALUES($1, $2, $3)
+								ON CONFLICT (team_id, lower(username)) DO NOTHING;`
+	getInvitationsByUsernameStmt = `SELECT i.id, i.team_id, t.match_id, i.username, i.invited_by, u.chat_id AS inviter_chat_id,
+								COALESCE(NULLIF(u.language, ''), u.language_code) AS inviter_language
+								FROM invitations i
+								JOIN teams t ON t.id = i.team_id
+								JOIN matches m ON m.id = t.match_id
+								JOIN users u ON u.id = i.invited_by
+								WHERE lower(i.username) = lower($1) AND m.cancelled = false AND m.start_at > NOW()
+								FOR UPDATE OF i;`
+	// the invitee is only added while the team has room and they are not
+	// in the match yet
+	claimInvitationStmt = `INSERT INTO team_members(team_id, member_id, confirmed)
+								SELECT $1, $2, false
+								WHERE NOT EXISTS (
+									SELECT 1 FROM team_members tm
+									JOIN teams t ON t.id = tm.team_id
+									WHERE t.match_id = (SELECT match_id FROM teams WHERE id = $1) AND tm.member_id = $2
+								) AND (SELECT count(*) FROM team_members WHERE team_id = $1) < (SELECT size FROM teams WHERE id = $1);`
+	deleteInvitationsByUsernameStmt = `DELETE FROM invitations WHERE lower(username) = lower($1);`
+)
+
+func (r *repository) CreateInvitation(ctx context.Context, teamID int64, username string, invitedBy int64) error {
+	_, err := r.pool.Exec(ctx, createInvitationStmt, teamID, username, invitedBy)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimInvitations turns every pending invitation for the username into a
+// team membership of userID and removes the invitations. It returns the
+// invitations that added the user, skipping those whose team is full or
+// whose match the user is already in.
+func (r *repository) ClaimInvitations(ctx context.Context, userID int64, username string) ([]*entity.Invitation, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var invitations []*entity.Invitation
+	if err := pgxscan.Select(ctx, tx, &invitations, getInvitationsByUsernameStmt, username); err != nil {
+		return nil, err
+	}
+	claimed := make([]*entity.Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		if _, err := tx.Exec(ctx, lockTeamStmt, []int64{invitation.TeamID}); err != nil {
+			return nil, err
+		}
+		tag, err := tx.Exec(ctx, claimInvitationStmt, invitation.TeamID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if tag.RowsAffected() == 1 {
+			claimed = append(claimed, invitation)
+		}
+	}
+	if _, err := tx.Exec(ctx, deleteInvitationsByUsernameStmt, username); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
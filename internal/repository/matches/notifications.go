@@ -0,0 +1,65 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	getNotificationSettingsStmt = `SELECT u.id AS user_id, COALESCE(ns.muted, '{}') AS muted, COALESCE(ns.digest, false) AS digest,
+							ns.quiet_from, ns.quiet_to, COALESCE(ns.utc_offset, $2) AS utc_offset FROM users u
+							LEFT JOIN notification_settings ns ON ns.user_id = u.id
+							WHERE u.id = $1;`
+	saveNotificationSettingsStmt = `INSERT INTO notification_settings(user_id, muted, digest, quiet_from, quiet_to, utc_offset) VALUES($1, $2, $3, $4, $5, $6)
+							ON CONFLICT (user_id) DO UPDATE SET muted = EXCLUDED.muted, digest = EXCLUDED.digest,
+							quiet_from = EXCLUDED.quiet_from, quiet_to = EXCLUDED.quiet_to, utc_offset = EXCLUDED.utc_offset;`
+	holdNotificationStmt           = `INSERT INTO pending_notifications(user_id, kind, text, parse_mode, reply_markup) VALUES($1, $2, $3, $4, $5);`
+	getPendingNotificationsStmt    = `SELECT id, user_id, kind, text, parse_mode, reply_markup, created_at FROM pending_notifications ORDER BY user_id, id;`
+	deletePendingNotificationsStmt = `DELETE FROM pending_notifications WHERE id = ANY($1);`
+)
+
+// GetNotificationSettings returns the settings of the user, the defaults
+// when they never changed them.
+func (r *repository) GetNotificationSettings(ctx context.Context, userID int64) (*entity.NotificationSettings, error) {
+	var settings entity.NotificationSettings
+	if err := pgxscan.Get(ctx, r.pool, &settings, getNotificationSettingsStmt, userID, entity.DefaultUTCOffset); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *repository) SaveNotificationSettings(ctx context.Context, s *entity.NotificationSettings) error {
+	_, err := r.pool.Exec(ctx, saveNotificationSettingsStmt, s.UserID, s.Muted, s.Digest, s.QuietFrom, s.QuietTo, s.UTCOffset)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) HoldNotification(ctx context.Context, n *entity.PendingNotification) error {
+	_, err := r.pool.Exec(ctx, holdNotificationStmt, n.UserID, n.Kind, n.Text, n.ParseMode, n.ReplyMarkup)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingNotifications returns the held notifications grouped by user,
+// oldest first.
+func (r *repository) GetPendingNotifications(ctx context.Context) ([]*entity.PendingNotification, error) {
+	var notifications []*entity.PendingNotification
+	if err := pgxscan.Select(ctx, r.pool, &notifications, getPendingNotificationsStmt); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *repository) DeletePendingNotifications(ctx context.Context, ids []int64) error {
+	_, err := r.pool.Exec(ctx, deletePendingNotificationsStmt, ids)
+	if err != nil {
+		return err
+	}
+	return nil
+}
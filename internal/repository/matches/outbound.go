@@ -0,0 +1,46 @@
+package matches
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	createOutboundMessageStmt = `INSERT INTO outbound_messages(chat_id, kind, status, attempts, error, payload, telegram_message_id, next_attempt_at)
+							VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;`
+	updateOutboundMessageStmt = `UPDATE outbound_messages SET status = $2, attempts = $3, error = $4, payload = $5,
+							telegram_message_id = $6, next_attempt_at = $7, updated_at = NOW() WHERE id = $1;`
+	getDueOutboundMessagesStmt = `SELECT id, chat_id, kind, status, attempts, error, payload, telegram_message_id, next_attempt_at, created_at
+							FROM outbound_messages WHERE status = 'pending' AND next_attempt_at <= $1
+							ORDER BY next_attempt_at LIMIT $2;`
+)
+
+func (r *repository) CreateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error {
+	if err := r.pool.QueryRow(ctx, createOutboundMessageStmt, m.ChatID, m.Kind, m.Status, m.Attempts, m.Error, m.Payload,
+		m.TelegramMessageID, m.NextAttemptAt).Scan(&m.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) UpdateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error {
+	_, err := r.pool.Exec(ctx, updateOutboundMessageStmt, m.ID, m.Status, m.Attempts, m.Error, m.Payload,
+		m.TelegramMessageID, m.NextAttemptAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDueOutboundMessages returns at most limit pending messages whose next
+// attempt is due, the longest waiting first.
+func (r *repository) GetDueOutboundMessages(ctx context.Context, now time.Time, limit int) ([]*entity.OutboundMessage, error) {
+	var messages []*entity.OutboundMessage
+	if err := pgxscan.Select(ctx, r.pool, &messages, getDueOutboundMessagesStmt, now, limit); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
@@ -0,0 +1,49 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	setPricingStmt  = `UPDATE matches SET pricing_model = $2, fixed_price = $3 WHERE id = $1;`
+	setDiscountStmt = `INSERT INTO match_discounts(match_id, user_id, percent) VALUES($1, $2, $3)
+								ON CONFLICT (match_id, user_id) DO UPDATE SET percent = EXCLUDED.percent;`
+	deleteDiscountStmt    = `DELETE FROM match_discounts WHERE match_id = $1 AND user_id = $2;`
+	getMatchDiscountsStmt = `SELECT match_id, user_id, percent FROM match_discounts WHERE match_id = $1;`
+)
+
+func (r *repository) SetPricing(ctx context.Context, matchID int64, model enum.PricingModel, fixedPrice int64) error {
+	_, err := r.pool.Exec(ctx, setPricingStmt, matchID, model, fixedPrice)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) SetDiscount(ctx context.Context, discount *entity.Discount) error {
+	_, err := r.pool.Exec(ctx, setDiscountStmt, discount.MatchID, discount.UserID, discount.Percent)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) DeleteDiscount(ctx context.Context, matchID, userID int64) error {
+	_, err := r.pool.Exec(ctx, deleteDiscountStmt, matchID, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetMatchDiscounts(ctx context.Context, matchID int64) ([]*entity.Discount, error) {
+	var discounts []*entity.Discount
+	if err := pgxscan.Select(ctx, r.pool, &discounts, getMatchDiscountsStmt, matchID); err != nil {
+		return nil, err
+	}
+	return discounts, nil
+}
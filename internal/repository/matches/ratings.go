@@ -0,0 +1,57 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	setPlayerRatingStmt = `INSERT INTO player_ratings(user_id, sport, rating) VALUES($1, $2, $3)
+							ON CONFLICT (user_id, sport) DO UPDATE SET rating = EXCLUDED.rating;`
+	moveTeamMemberStmt = `UPDATE team_members SET team_id = $3 WHERE member_id = $1 AND team_id = $2;`
+	overfullTeamsStmt  = `SELECT t.id FROM teams t
+							WHERE t.id = ANY($1) AND (SELECT count(*) FROM team_members WHERE team_id = t.id) > t.size;`
+)
+
+func (r *repository) SetPlayerRating(ctx context.Context, userID int64, sport enum.SportType, rating int64) error {
+	_, err := r.pool.Exec(ctx, setPlayerRatingStmt, userID, sport, rating)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// MoveTeamMembers applies all the moves or none of them. The teams the
+// players move to are locked, and the moves fail with a Conflict error when
+// any of those teams ends up with more players than its size.
+func (r *repository) MoveTeamMembers(ctx context.Context, moves []*entity.TeamMove) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	teamIDs := make([]int64, 0, len(moves))
+	for _, move := range moves {
+		teamIDs = append(teamIDs, move.ToTeamID)
+	}
+	if _, err := tx.Exec(ctx, lockTeamStmt, teamIDs); err != nil {
+		return err
+	}
+	for _, move := range moves {
+		if _, err := tx.Exec(ctx, moveTeamMemberStmt, move.User.ID, move.FromTeamID, move.ToTeamID); err != nil {
+			return err
+		}
+	}
+	var overfull []int64
+	if err := pgxscan.Select(ctx, tx, &overfull, overfullTeamsStmt, teamIDs); err != nil {
+		return err
+	}
+	if len(overfull) != 0 {
+		return errors.Conflict.Newf("team %d is full", overfull[0])
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,35 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	markUnreachableStmt = `UPDATE users SET unreachable = $2, unreachable_since = NOW()
+							WHERE chat_id = $1 AND unreachable <> $2;`
+	getUnreachableUsersStmt = `SELECT id, telegram_id, name, username, chat_id, unreachable, ` + languageColumn + ` FROM users
+							WHERE id = ANY($1) AND unreachable <> '' ORDER BY name;`
+)
+
+// MarkUnreachable flags the user of the private chat as one the bot can't
+// write to. The flag is cleared by UpsertUser when they write again.
+func (r *repository) MarkUnreachable(ctx context.Context, chatID int64, reason enum.UnreachableReason) error {
+	_, err := r.pool.Exec(ctx, markUnreachableStmt, chatID, reason)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetUnreachableUsers returns those of the users the bot can't write to.
+func (r *repository) GetUnreachableUsers(ctx context.Context, userIDs []int64) ([]*entity.User, error) {
+	var users []*entity.User
+	if err := pgxscan.Select(ctx, r.pool, &users, getUnreachableUsersStmt, userIDs); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
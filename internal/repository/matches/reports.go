@@ -0,0 +1,83 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	// messages of an album arrive at the same time, so the draft is created
+	// or found in a single statement
+	getDraftReportStmt = `INSERT INTO match_reports(match_id, author_id) VALUES($1, $2)
+							ON CONFLICT (match_id, author_id) WHERE published = false
+							DO UPDATE SET match_id = EXCLUDED.match_id
+							RETURNING id, match_id, author_id, text, published, created_at;`
+	appendReportTextStmt = `UPDATE match_reports
+							SET text = CASE WHEN text = '' THEN $2 ELSE text || E'\n' || $2 END
+							WHERE id = $1;`
+	addReportAttachmentStmt = `INSERT INTO report_attachments(report_id, kind, file_id) VALUES($1, $2, $3);`
+	publishReportStmt       = `UPDATE match_reports SET published = true, created_at = NOW() WHERE id = $1;`
+	getReportStmt           = `SELECT id, match_id, author_id, text, published, created_at FROM match_reports WHERE id = $1;`
+	getMatchReportsStmt     = `SELECT id, match_id, author_id, text, published, created_at FROM match_reports
+							WHERE match_id = $1 AND published = true
+							ORDER BY created_at DESC LIMIT $2;`
+	getReportAttachmentsStmt = `SELECT id, report_id, kind, file_id FROM report_attachments WHERE report_id = $1 ORDER BY id;`
+)
+
+func (r *repository) GetDraftReport(ctx context.Context, matchID, authorID int64) (*entity.Report, error) {
+	var report entity.Report
+	if err := pgxscan.Get(ctx, r.pool, &report, getDraftReportStmt, matchID, authorID); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *repository) AppendReportText(ctx context.Context, reportID int64, text string) error {
+	_, err := r.pool.Exec(ctx, appendReportTextStmt, reportID, text)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) AddReportAttachment(ctx context.Context, attachment *entity.Attachment) error {
+	_, err := r.pool.Exec(ctx, addReportAttachmentStmt, attachment.ReportID, attachment.Kind, attachment.FileID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) PublishReport(ctx context.Context, reportID int64) error {
+	_, err := r.pool.Exec(ctx, publishReportStmt, reportID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetReport(ctx context.Context, reportID int64) (*entity.Report, error) {
+	var report entity.Report
+	if err := pgxscan.Get(ctx, r.pool, &report, getReportStmt, reportID); err != nil {
+		return nil, err
+	}
+	if err := pgxscan.Select(ctx, r.pool, &report.Attachments, getReportAttachmentsStmt, reportID); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *repository) GetMatchReports(ctx context.Context, matchID int64, limit int) ([]*entity.Report, error) {
+	var reports []*entity.Report
+	if err := pgxscan.Select(ctx, r.pool, &reports, getMatchReportsStmt, matchID, limit); err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		if err := pgxscan.Select(ctx, r.pool, &report.Attachments, getReportAttachmentsStmt, report.ID); err != nil {
+			return nil, err
+		}
+	}
+	return reports, nil
+}
@@ -2,9 +2,11 @@ package matches
 
 import (
 	"context"
+	"time"
 
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -14,19 +16,102 @@ type Repository interface {
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 	GetUserByID(ctx context.Context, id int64) (*entity.User, error)
 	AddTeamMembers(ctx context.Context, teamID int64, userIDs []int64) error
-	CreateUser(ctx context.Context, user *entity.User) (*entity.User, error)
+	UpsertUser(ctx context.Context, user *entity.User) (_ *entity.User, renamed bool, err error)
+	SetUserLanguage(ctx context.Context, userID int64, language string) error
+	GetUserByTelegramID(ctx context.Context, telegramID int64) (*entity.User, error)
 	GetMatch(ctx context.Context, matchID int64) (*entity.Match, error)
 	GetTeamsByMatchID(ctx context.Context, matchID int64) ([]*entity.Team, error)
 	GetTeamMembers(ctx context.Context, teamID int64) ([]*entity.User, error)
 	GetMatchIDByTeamID(ctx context.Context, id int64) (int64, error)
-	GetOpenMatchesBySport(ctx context.Context, sport enum.SportType) ([]*entity.Match, error)
+	SearchMatches(ctx context.Context, filter *entity.MatchFilter) ([]*entity.Match, error)
 	SetMatchConfirmed(ctx context.Context, confirmed bool, memberID, matchID int64) error
-	SetMatchPaid(ctx context.Context, paid bool, memberID, matchID int64) error
+	SetMatchPaid(ctx context.Context, paid bool, amount, memberID, matchID int64) error
 	SignUpToMatch(ctx context.Context, userID, matchID int64) error
-	DeleteTeamMember(ctx context.Context, memberID, matchID int64) error
+	DeleteTeamMember(ctx context.Context, matchID, memberID int64) error
 	CancelMatch(ctx context.Context, matchID int64) error
 	GetMatchesByUserID(ctx context.Context, userID int64) ([]*entity.Match, error)
 	GetMatchesByOrganizerID(ctx context.Context, userID int64) ([]*entity.Match, error)
+	CreateInvitation(ctx context.Context, teamID int64, username string, invitedBy int64) error
+	ClaimInvitations(ctx context.Context, userID int64, username string) ([]*entity.Invitation, error)
+	CreateVenue(ctx context.Context, venue *entity.Venue) (*entity.Venue, error)
+	GetVenue(ctx context.Context, id int64) (*entity.Venue, error)
+	GetVenueByName(ctx context.Context, name string) (*entity.Venue, error)
+	GetVenuesBySport(ctx context.Context, sport string, limit int) ([]*entity.Venue, error)
+	GetVenuesByCreator(ctx context.Context, userID int64) ([]*entity.Venue, error)
+	SetVenueLocation(ctx context.Context, id int64, point entity.GeoPoint, address string) error
+	SetVenuePhoto(ctx context.Context, id int64, fileID string) error
+	SetVenueDefaultRent(ctx context.Context, id, rent int64) error
+	AddVenueSport(ctx context.Context, id int64, sport string) error
+	SetVenueConflictPolicy(ctx context.Context, id int64, policy enum.ConflictPolicy) error
+	SetPlayerRating(ctx context.Context, userID int64, sport enum.SportType, rating int64) error
+	MoveTeamMembers(ctx context.Context, moves []*entity.TeamMove) error
+	GetVenueMatches(ctx context.Context, venueID int64, from, to time.Time, excludeID int64) ([]*entity.Match, error)
+	RescheduleMatch(ctx context.Context, matchID int64, startAt, finishAt time.Time) error
+	SetTeamScores(ctx context.Context, scores map[int64]int64) error
+	AddPlayerGoals(ctx context.Context, matchID, userID, delta int64) error
+	SetMatchMVP(ctx context.Context, matchID, userID int64) error
+	GetMatchesAwaitingResults(ctx context.Context, now time.Time) ([]*entity.Match, error)
+	SetResultsRequested(ctx context.Context, matchID int64) error
+	GetPlayerStats(ctx context.Context, userID int64) ([]*entity.PlayerStats, error)
+	SetAttendance(ctx context.Context, matchID, userID int64, attended bool) error
+	RecordCancellation(ctx context.Context, cancellation *entity.Cancellation) error
+	CreateSpotTransfer(ctx context.Context, transfer *entity.SpotTransfer) (*entity.SpotTransfer, error)
+	GetSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error)
+	AcceptSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error)
+	DeclineSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error)
+	UpsertClub(ctx context.Context, club *entity.Club) (*entity.Club, error)
+	GetClub(ctx context.Context, id int64) (*entity.Club, error)
+	GetClubsByOwner(ctx context.Context, ownerID int64) ([]*entity.Club, error)
+	SetClubEmoji(ctx context.Context, id int64, emoji string) error
+	UpdateTeam(ctx context.Context, team *entity.Team) error
+	AddExpense(ctx context.Context, expense *entity.Expense) error
+	GetExpenses(ctx context.Context, matchID int64) ([]*entity.Expense, error)
+	DeleteExpenses(ctx context.Context, matchID int64) error
+	GetDraftReport(ctx context.Context, matchID, authorID int64) (*entity.Report, error)
+	AppendReportText(ctx context.Context, reportID int64, text string) error
+	AddReportAttachment(ctx context.Context, attachment *entity.Attachment) error
+	PublishReport(ctx context.Context, reportID int64) error
+	GetReport(ctx context.Context, reportID int64) (*entity.Report, error)
+	GetMatchReports(ctx context.Context, matchID int64, limit int) ([]*entity.Report, error)
+	SetCancelWindow(ctx context.Context, matchID, hours int64) error
+	GetReliability(ctx context.Context, userID int64) (*entity.Reliability, error)
+	SetMinReliability(ctx context.Context, matchID int64, minReliability *int64) error
+	SetPricing(ctx context.Context, matchID int64, model enum.PricingModel, fixedPrice int64) error
+	SetDiscount(ctx context.Context, discount *entity.Discount) error
+	DeleteDiscount(ctx context.Context, matchID, userID int64) error
+	GetMatchDiscounts(ctx context.Context, matchID int64) ([]*entity.Discount, error)
+	GetSports(ctx context.Context) ([]*entity.Sport, error)
+	GetSport(ctx context.Context, code enum.SportType) (*entity.Sport, error)
+	SaveSport(ctx context.Context, sport *entity.Sport) error
+	SetEligibility(ctx context.Context, matchID int64, e *entity.Eligibility) error
+	GetProfile(ctx context.Context, userID int64, sport enum.SportType) (*entity.Profile, error)
+	SetGender(ctx context.Context, userID int64, gender enum.Gender) error
+	SetBirthYear(ctx context.Context, userID, year int64) error
+	CreateChatMessage(ctx context.Context, message *entity.ChatMessage) error
+	CountChatMessages(ctx context.Context, senderID int64, since time.Time) (int64, error)
+	AddChatCopy(ctx context.Context, c *entity.ChatCopy) error
+	GetChatCopies(ctx context.Context, messageID int64) ([]*entity.ChatCopy, error)
+	GetChatMessageByCopy(ctx context.Context, chatID int64, telegramMessageID int) (*entity.ChatMessage, error)
+	SetChatMuted(ctx context.Context, matchID, userID int64, muted bool) error
+	GetChatMutedUsers(ctx context.Context, matchID int64) ([]int64, error)
+	LinkMatchChat(ctx context.Context, chat *entity.MatchChat) error
+	UnlinkMatchChat(ctx context.Context, matchID int64) error
+	GetMatchChat(ctx context.Context, matchID int64) (*entity.MatchChat, error)
+	SaveChatInvite(ctx context.Context, invite *entity.ChatInvite) error
+	GetChatInvite(ctx context.Context, matchID, userID int64) (*entity.ChatInvite, error)
+	GetChatInvites(ctx context.Context, matchID int64) ([]*entity.ChatInvite, error)
+	DeleteChatInvite(ctx context.Context, matchID, userID int64) error
+	CountLinkedMatches(ctx context.Context, chatID, userID, exceptMatchID int64) (int64, error)
+	GetNotificationSettings(ctx context.Context, userID int64) (*entity.NotificationSettings, error)
+	SaveNotificationSettings(ctx context.Context, s *entity.NotificationSettings) error
+	HoldNotification(ctx context.Context, n *entity.PendingNotification) error
+	GetPendingNotifications(ctx context.Context) ([]*entity.PendingNotification, error)
+	DeletePendingNotifications(ctx context.Context, ids []int64) error
+	CreateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error
+	UpdateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error
+	GetDueOutboundMessages(ctx context.Context, now time.Time, limit int) ([]*entity.OutboundMessage, error)
+	MarkUnreachable(ctx context.Context, chatID int64, reason enum.UnreachableReason) error
+	GetUnreachableUsers(ctx context.Context, userIDs []int64) ([]*entity.User, error)
 }
 
 type repository struct {
@@ -37,33 +122,56 @@ func New(pool *pgxpool.Pool) Repository {
 	return &repository{pool: pool}
 }
 
+// languageColumn is the language the user chose in the bot, or else the
+// language of their Telegram app.
 const (
-	createMatchStmt = `INSERT INTO matches(sport, organizer_id, location,team_size, team_count, rent, start_at, finish_at, private)
-						VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	languageColumn       = `COALESCE(NULLIF(language, ''), language_code) AS language`
+	memberLanguageColumn = `COALESCE(NULLIF(u.language, ''), u.language_code) AS language`
+)
+
+const (
+	createMatchStmt = `INSERT INTO matches(sport, organizer_id, location,team_size, team_count, rent, start_at, finish_at, private, venue_id)
+						VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
 						RETURNING id;`
-	createUserStmt        = `INSERT INTO users(name, username, chat_id) VALUES($1, $2, $3) RETURNING id;`
-	getUserByUsernameStmt = `SELECT id, name, username, chat_id FROM users WHERE username=$1;`
-	createTeamStmt        = `INSERT INTO teams(name,size,match_id) VALUES($1, $2, $3);`
-	getTeamsByMatchIDStmt = `SELECT id, name, size FROM teams WHERE match_id=$1`
-	getMatchByIDStmt      = `SELECT id, sport,organizer_id, location,team_size,team_count,rent,start_at, finish_at
+	// the previous username is read from the snapshot taken before the
+	// upsert, telling whether the user is new or got a new username
+	upsertUserStmt = `WITH previous AS (SELECT username FROM users WHERE telegram_id = $1)
+							INSERT INTO users(telegram_id, name, username, chat_id, language_code) VALUES($1, $2, $3, $4, $5)
+							ON CONFLICT (telegram_id) DO UPDATE
+							SET name = EXCLUDED.name, username = EXCLUDED.username, chat_id = EXCLUDED.chat_id,
+								language_code = EXCLUDED.language_code, unreachable = '', unreachable_since = NULL
+							RETURNING id, COALESCE(NULLIF(language, ''), language_code),
+								NOT EXISTS (SELECT 1 FROM previous WHERE lower(previous.username) = lower($3));`
+	setUserLanguageStmt   = `UPDATE users SET language = $2 WHERE id = $1;`
+	releaseUsernameStmt   = `UPDATE users SET username = '' WHERE lower(username) = lower($1) AND telegram_id <> $2;`
+	getUserByUsernameStmt = `SELECT id, telegram_id, name, username, chat_id, unreachable, ` + languageColumn + ` FROM users
+								WHERE lower(username) = lower($1) AND username <> ''
+								ORDER BY id DESC LIMIT 1;`
+	getUserByTelegramIDStmt = `SELECT id, telegram_id, name, username, chat_id, unreachable, ` + languageColumn + ` FROM users WHERE telegram_id=$1;`
+	createTeamStmt          = `INSERT INTO teams(name,size,match_id,emoji) VALUES($1, $2, $3, $4);`
+	getTeamsByMatchIDStmt   = `SELECT t.id, t.name, t.size, t.emoji, t.club_id, ts.score FROM teams t
+								LEFT JOIN team_scores ts ON ts.team_id = t.id
+								WHERE t.match_id=$1 ORDER BY t.id`
+	getMatchByIDStmt = `SELECT id, sport,organizer_id, location,team_size,team_count,rent,start_at, finish_at, venue_id, min_reliability, cancel_window_hours, pricing_model, fixed_price,
+								min_level, max_level, gender, min_age, max_age
 								FROM matches WHERE id = $1 AND cancelled=false;`
-	createTeamMemberStmt   = `INSERT INTO team_members(team_id, member_id, confirmed) VALUES($1, $2, $3);`
-	getMembersByTeamIDStmt = `SELECT u.id, u.name, u.username, u.chat_id, tm.confirmed, tm.paid, tm.cancelled 
+	createTeamMemberStmt = `INSERT INTO team_members(team_id, member_id, confirmed)
+								SELECT $1, $2, $3
+								WHERE (SELECT count(*) FROM team_members WHERE team_id = $1) < (SELECT size FROM teams WHERE id = $1);`
+	getMembersByTeamIDStmt = `SELECT u.id, u.telegram_id, u.name, u.username, u.chat_id, u.unreachable, ` + memberLanguageColumn + `, tm.confirmed, tm.paid, tm.paid_amount, tm.cancelled, tm.attended,
+								COALESCE(pr.rating, 1000) AS rating,
+								COALESCE(ps.goals, 0) AS goals, COALESCE(ps.mvp, false) AS mvp
 								FROM team_members tm 
 								LEFT JOIN users u 
 								ON tm.member_id = u.id
+								LEFT JOIN teams t ON t.id = tm.team_id
+								LEFT JOIN matches m ON m.id = t.match_id
+								LEFT JOIN player_ratings pr ON pr.user_id = u.id AND pr.sport = m.sport::text
+								LEFT JOIN player_match_stats ps ON ps.match_id = m.id AND ps.user_id = u.id
 								WHERE tm.team_id = $1;`
-	getUserByIDStmt           = `SELECT id, name, username, chat_id FROM users WHERE id=$1;`
-	getOpenMatchesBySportStmt = `SELECT m.id,m.team_size,m.team_count, m.rent,m.start_at, m.finish_at, count(tm.member_id) as members_count
-									FROM matches m
-									LEFT JOIN teams t ON m.id = t.match_id
-									LEFT JOIN team_members tm ON t.id = tm.team_id
-									WHERE private=false AND sport=$1 AND start_at - interval '30 minutes' > NOW() AND m.cancelled = false
-									GROUP BY m.id,m.team_size,m.team_count, m.rent,m.start_at, m.finish_at
-									ORDER BY m.start_at DESC;
-									`
+	getUserByIDStmt         = `SELECT id, telegram_id, name, username, chat_id, unreachable, ` + languageColumn + ` FROM users WHERE id=$1;`
 	setMatchConfirmedStmt   = `UPDATE team_members SET confirmed=$1 WHERE member_id=$2 AND team_id=$3;`
-	setMatchPaidStmt        = `UPDATE team_members SET paid=$1 WHERE member_id=$2 AND team_id=$3;`
+	setMatchPaidStmt        = `UPDATE team_members SET paid=$1, paid_amount=$4 WHERE member_id=$2 AND team_id=$3;`
 	deleteTeamMemberStmt    = `DELETE FROM team_members WHERE member_id = $2 AND team_id=$1;`
 	getMatchIDByTeamIDStmt  = `SELECT match_id as id FROM teams WHERE id=$1;`
 	getTeamIDByMatchAndUser = `SELECT t.id AS id
@@ -72,16 +180,20 @@ const (
 								ON t.id=tm.team_id
 								WHERE t.match_id=$1 AND tm.member_id = $2;
 								`
-	signUpToMatchStmt      = `INSERT INTO team_members (team_id, member_id, confirmed) VALUES ($2, $1, true);`
+	signUpToMatchStmt = `INSERT INTO team_members (team_id, member_id, confirmed)
+								SELECT $2, $1, true
+								WHERE (SELECT count(*) FROM team_members WHERE team_id = $2) < (SELECT size FROM teams WHERE id = $2);`
+	lockTeamStmt           = `SELECT id FROM teams WHERE id = ANY($1) ORDER BY id FOR UPDATE;`
 	cancelMatchStmt        = `UPDATE matches SET cancelled = true WHERE id=$1;`
-	getMatchesByUserIDStmt = `SELECT m.id,m.team_size,m.team_count, m.rent,m.start_at, m.finish_at, count(tm.member_id) as members_count
+	rescheduleMatchStmt    = `UPDATE matches SET start_at = $2, finish_at = $3 WHERE id=$1;`
+	getMatchesByUserIDStmt = `SELECT m.id,m.team_size,m.team_count, m.rent, m.pricing_model, m.fixed_price, m.start_at, m.finish_at, count(tm.member_id) as members_count
 								FROM matches m
 								LEFT JOIN teams t ON m.id = t.match_id
 								LEFT JOIN team_members tm ON t.id = tm.team_id
 								WHERE tm.member_id=$1 AND start_at - interval '30 minutes' > NOW() AND m.cancelled = false
 								GROUP BY m.id,m.team_size,m.team_count, m.rent,m.start_at, m.finish_at
 								ORDER BY m.start_at DESC;`
-	getMatchesByOrganizerIDStmt = `SELECT m.id,m.team_size,m.team_count, m.rent,m.start_at, m.finish_at, count(tm.member_id) as members_count
+	getMatchesByOrganizerIDStmt = `SELECT m.id,m.team_size,m.team_count, m.rent, m.pricing_model, m.fixed_price, m.start_at, m.finish_at, count(tm.member_id) as members_count
 								FROM matches m
 								LEFT JOIN teams t ON m.id = t.match_id
 								LEFT JOIN team_members tm ON t.id = tm.team_id
@@ -116,12 +228,55 @@ func (r *repository) CancelMatch(ctx context.Context, matchID int64) error {
 	return nil
 }
 
+// RescheduleMatch moves the match to [startAt, finishAt). The venue row is
+// locked while the overlap is checked and the match is moved, so that two
+// organizers can't take the same slot of a venue that blocks overlapping
+// bookings. Such an overlap fails with a Conflict error.
+func (r *repository) RescheduleMatch(ctx context.Context, matchID int64, startAt, finishAt time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	var policies []enum.ConflictPolicy
+	if err := pgxscan.Select(ctx, tx, &policies, lockMatchVenueStmt, matchID); err != nil {
+		return err
+	}
+	if len(policies) != 0 && policies[0] == enum.ConflictPolicyBlock {
+		var busy bool
+		if err := tx.QueryRow(ctx, venueBusyStmt, matchID, startAt, finishAt).Scan(&busy); err != nil {
+			return err
+		}
+		if busy {
+			return errors.Conflict.Newf("venue of match %d is booked at %s", matchID, startAt.Format(time.RFC3339))
+		}
+	}
+	if _, err := tx.Exec(ctx, rescheduleMatchStmt, matchID, startAt, finishAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SignUpToMatch adds the player to the team. The team row is locked while
+// its members are counted, so that concurrent sign-ups can't overfill it; a
+// full team fails with a Conflict error.
 func (r *repository) SignUpToMatch(ctx context.Context, userID, teamID int64) error {
-	_, err := r.pool.Exec(ctx, signUpToMatchStmt, userID, teamID)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, lockTeamStmt, []int64{teamID}); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, signUpToMatchStmt, userID, teamID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.Conflict.Newf("team %d is full", teamID)
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *repository) GetMatchIDByTeamID(ctx context.Context, id int64) (int64, error) {
@@ -158,12 +313,12 @@ func (r *repository) SetMatchConfirmed(ctx context.Context, confirmed bool, memb
 	return nil
 }
 
-func (r *repository) SetMatchPaid(ctx context.Context, paid bool, memberID, matchID int64) error {
+func (r *repository) SetMatchPaid(ctx context.Context, paid bool, amount, memberID, matchID int64) error {
 	teamID, err := r.GetTeamIDByMatchAndUser(ctx, matchID, memberID)
 	if err != nil {
 		return err
 	}
-	_, err = r.pool.Exec(ctx, setMatchPaidStmt, paid, memberID, teamID)
+	_, err = r.pool.Exec(ctx, setMatchPaidStmt, paid, memberID, teamID, amount)
 	if err != nil {
 		return err
 	}
@@ -182,15 +337,6 @@ func (r *repository) DeleteTeamMember(ctx context.Context, matchID, memberID int
 	return nil
 }
 
-func (r *repository) GetOpenMatchesBySport(ctx context.Context, sport enum.SportType) ([]*entity.Match, error) {
-	var matches []*entity.Match
-	err := pgxscan.Select(ctx, r.pool, &matches, getOpenMatchesBySportStmt, sport)
-	if err != nil {
-		return nil, err
-	}
-	return matches, nil
-}
-
 func (r *repository) GetTeamMembers(ctx context.Context, teamID int64) ([]*entity.User, error) {
 	var users []*entity.User
 	err := pgxscan.Select(ctx, r.pool, &users, getMembersByTeamIDStmt, teamID)
@@ -201,14 +347,28 @@ func (r *repository) GetTeamMembers(ctx context.Context, teamID int64) ([]*entit
 
 }
 
+// AddTeamMembers adds the players to the team, all of them or none. As in
+// SignUpToMatch, the team row is locked while its members are counted; a
+// team without room for all of them fails with a Conflict error.
 func (r *repository) AddTeamMembers(ctx context.Context, teamID int64, userIDs []int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, lockTeamStmt, []int64{teamID}); err != nil {
+		return err
+	}
 	for _, id := range userIDs {
-		_, err := r.pool.Exec(ctx, createTeamMemberStmt, teamID, id, false)
+		tag, err := tx.Exec(ctx, createTeamMemberStmt, teamID, id, false)
 		if err != nil {
 			return err
 		}
+		if tag.RowsAffected() == 0 {
+			return errors.Conflict.Newf("team %d is full", teamID)
+		}
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (r *repository) GetUserByID(ctx context.Context, id int64) (*entity.User, error) {
@@ -236,26 +396,52 @@ func (r *repository) GetTeamsByMatchID(ctx context.Context, matchID int64) ([]*e
 	return teams, nil
 }
 
+// CreateMatch creates the match with its teams. As in RescheduleMatch, the
+// venue row is locked while the overlap is checked, so that two organizers
+// can't book the same slot of a venue that blocks overlapping bookings.
+// Such an overlap fails with a Conflict error.
 func (r *repository) CreateMatch(ctx context.Context, match *entity.Match) (*entity.Match, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
 	var id int64
-	if err := r.pool.QueryRow(ctx, createMatchStmt, match.Type,
+	if err := tx.QueryRow(ctx, createMatchStmt, match.Type,
 		match.OrganizerID, match.Location,
 		match.TeamSize, match.TeamCount,
 		match.Rent, match.StartAt,
-		match.FinishAt, match.IsPrivate).Scan(&id); err != nil {
+		match.FinishAt, match.IsPrivate, match.VenueID).Scan(&id); err != nil {
 		return nil, err
 	}
+	var policies []enum.ConflictPolicy
+	if err := pgxscan.Select(ctx, tx, &policies, lockMatchVenueStmt, id); err != nil {
+		return nil, err
+	}
+	if len(policies) != 0 && policies[0] == enum.ConflictPolicyBlock {
+		var busy bool
+		if err := tx.QueryRow(ctx, venueBusyStmt, id, match.StartAt, match.FinishAt).Scan(&busy); err != nil {
+			return nil, err
+		}
+		if busy {
+			return nil, errors.Conflict.Newf("venue %d is booked at %s", *match.VenueID, match.StartAt.Format(time.RFC3339))
+		}
+	}
 	for _, team := range match.Teams {
-		r.pool.Exec(ctx, createTeamStmt, team.Name, team.Size, id)
+		if _, err := tx.Exec(ctx, createTeamStmt, team.Name, team.Size, id, team.Emoji); err != nil {
+			return nil, err
+		}
 	}
 	var teams []*entity.Team
-	if err := pgxscan.Select(ctx, r.pool, &teams, getTeamsByMatchIDStmt, id); err != nil {
+	if err := pgxscan.Select(ctx, tx, &teams, getTeamsByMatchIDStmt, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 	match.Teams = teams
 	match.ID = id
 	return match, nil
-
 }
 
 func (r *repository) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
@@ -266,11 +452,39 @@ func (r *repository) GetUserByUsername(ctx context.Context, username string) (*e
 	return &user, nil
 }
 
-func (r *repository) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
-	var id int64
-	if err := r.pool.QueryRow(ctx, createUserStmt, user.Name, user.Username, user.ChatID).Scan(&id); err != nil {
+func (r *repository) GetUserByTelegramID(ctx context.Context, telegramID int64) (*entity.User, error) {
+	var user entity.User
+	if err := pgxscan.Get(ctx, r.pool, &user, getUserByTelegramIDStmt, telegramID); err != nil {
 		return nil, err
 	}
+	return &user, nil
+}
+
+// UpsertUser creates the user or refreshes the mutable attributes (name,
+// username, chat, Telegram language) of an existing one. A username can
+// only belong to one Telegram account at a time, so it is released from any
+// stale owner. The user comes back with the language they talk to the bot
+// in: the one they chose, or else the language of their Telegram app.
+// renamed is true when the user is new or their username changed.
+func (r *repository) UpsertUser(ctx context.Context, user *entity.User) (_ *entity.User, renamed bool, err error) {
+	if user.Username != "" {
+		if _, err := r.pool.Exec(ctx, releaseUsernameStmt, user.Username, user.TelegramID); err != nil {
+			return nil, false, err
+		}
+	}
+	var id int64
+	if err := r.pool.QueryRow(ctx, upsertUserStmt, user.TelegramID, user.Name, user.Username, user.ChatID, user.Language).
+		Scan(&id, &user.Language, &renamed); err != nil {
+		return nil, false, err
+	}
 	user.ID = id
-	return user, nil
+	return user, renamed, nil
+}
+
+func (r *repository) SetUserLanguage(ctx context.Context, userID int64, language string) error {
+	_, err := r.pool.Exec(ctx, setUserLanguageStmt, userID, language)
+	if err != nil {
+		return err
+	}
+	return nil
 }
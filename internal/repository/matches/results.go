@@ -0,0 +1,118 @@
+package matches
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	setTeamScoreStmt = `INSERT INTO team_scores(team_id, score) VALUES($1, $2)
+							ON CONFLICT (team_id) DO UPDATE SET score = EXCLUDED.score;`
+	addPlayerGoalsStmt = `INSERT INTO player_match_stats(match_id, user_id, goals) VALUES($1, $2, GREATEST($3, 0))
+							ON CONFLICT (match_id, user_id) DO UPDATE
+							SET goals = GREATEST(player_match_stats.goals + $3, 0);`
+	resetMatchMVPStmt = `UPDATE player_match_stats SET mvp = false WHERE match_id = $1;`
+	setMatchMVPStmt   = `INSERT INTO player_match_stats(match_id, user_id, mvp) VALUES($1, $2, true)
+							ON CONFLICT (match_id, user_id) DO UPDATE SET mvp = true;`
+	getMatchesAwaitingResultsStmt = `SELECT m.id, m.sport, m.organizer_id, m.location, m.team_size, m.team_count, m.rent, m.start_at, m.finish_at
+							FROM matches m
+							WHERE m.finish_at < $1 AND m.cancelled = false AND m.results_requested = false
+							AND NOT EXISTS (
+								SELECT 1 FROM teams t JOIN team_scores ts ON ts.team_id = t.id WHERE t.match_id = m.id
+							)
+							ORDER BY m.finish_at;`
+	setResultsRequestedStmt = `UPDATE matches SET results_requested = true WHERE id = $1;`
+	// a team wins when it alone has the top score of the match and draws
+	// when it shares the top score with another team
+	getPlayerStatsStmt = `WITH scored AS (
+								SELECT t.id AS team_id, t.match_id, m.sport, ts.score,
+									MAX(ts.score) OVER (PARTITION BY t.match_id) AS top
+								FROM teams t
+								JOIN team_scores ts ON ts.team_id = t.id
+								JOIN matches m ON m.id = t.match_id
+								WHERE m.cancelled = false
+							), ranked AS (
+								SELECT s.*, COUNT(*) FILTER (WHERE s.score = s.top) OVER (PARTITION BY s.match_id) AS leaders
+								FROM scored s
+							)
+							SELECT r.sport, COUNT(*) AS played,
+								COUNT(*) FILTER (WHERE r.score = r.top AND r.leaders = 1) AS wins,
+								COUNT(*) FILTER (WHERE r.score = r.top AND r.leaders > 1) AS draws,
+								COUNT(*) FILTER (WHERE r.score < r.top) AS losses,
+								COALESCE(SUM(ps.goals), 0) AS goals,
+								COUNT(*) FILTER (WHERE ps.mvp) AS mvp
+							FROM ranked r
+							JOIN team_members tm ON tm.team_id = r.team_id AND tm.cancelled = false
+							LEFT JOIN player_match_stats ps ON ps.match_id = r.match_id AND ps.user_id = tm.member_id
+							WHERE tm.member_id = $1
+							GROUP BY r.sport
+							ORDER BY played DESC;`
+)
+
+// SetTeamScores saves the scores of all teams of a match at once.
+func (r *repository) SetTeamScores(ctx context.Context, scores map[int64]int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	for teamID, score := range scores {
+		if _, err := tx.Exec(ctx, setTeamScoreStmt, teamID, score); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *repository) AddPlayerGoals(ctx context.Context, matchID, userID, delta int64) error {
+	_, err := r.pool.Exec(ctx, addPlayerGoalsStmt, matchID, userID, delta)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetMatchMVP makes the user the only MVP of the match.
+func (r *repository) SetMatchMVP(ctx context.Context, matchID, userID int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, resetMatchMVPStmt, matchID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, setMatchMVPStmt, matchID, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetMatchesAwaitingResults returns the finished matches whose organizer
+// has not been asked for the score yet.
+func (r *repository) GetMatchesAwaitingResults(ctx context.Context, now time.Time) ([]*entity.Match, error) {
+	var matches []*entity.Match
+	if err := pgxscan.Select(ctx, r.pool, &matches, getMatchesAwaitingResultsStmt, now); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func (r *repository) SetResultsRequested(ctx context.Context, matchID int64) error {
+	_, err := r.pool.Exec(ctx, setResultsRequestedStmt, matchID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetPlayerStats(ctx context.Context, userID int64) ([]*entity.PlayerStats, error) {
+	var stats []*entity.PlayerStats
+	if err := pgxscan.Select(ctx, r.pool, &stats, getPlayerStatsStmt, userID); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
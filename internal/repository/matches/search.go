@@ -0,0 +1,141 @@
+package matches
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const searchMatchesStmt = `SELECT m.id, m.sport, m.location, m.venue_id, m.team_size, m.team_count, m.rent, m.start_at, m.finish_at,
+								m.pricing_model, m.fixed_price,
+								count(tm.member_id) AS members_count, COALESCE(sum(md.percent), 0) AS members_discount,
+								%s AS distance
+								FROM matches m
+								LEFT JOIN venues v ON v.id = m.venue_id
+								LEFT JOIN teams t ON m.id = t.match_id
+								LEFT JOIN team_members tm ON t.id = tm.team_id
+								LEFT JOIN match_discounts md ON md.match_id = m.id AND md.user_id = tm.member_id
+								WHERE %s
+								GROUP BY m.id, v.id
+								HAVING %s
+								ORDER BY %s
+								LIMIT %s OFFSET %s;`
+
+// distanceExpr is the great-circle distance in kilometres from the point
+// ($lat, $lon) to the venue, NULL for venues without coordinates.
+const distanceExpr = `6371 * acos(least(1, cos(radians(%[1]s)) * cos(radians(v.latitude)) * cos(radians(v.longitude) - radians(%[2]s))
+								+ sin(radians(%[1]s)) * sin(radians(v.latitude))))`
+
+const defaultSearchRadiusKm = 10
+
+// SearchMatches returns public upcoming matches matching the filter,
+// ordered by start time. The price a newcomer pays is only computed by
+// entity.Match.JoinPrice, so a search with a highest price pages through
+// the matches after filtering them here.
+func (r *repository) SearchMatches(ctx context.Context, filter *entity.MatchFilter) ([]*entity.Match, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where := []string{
+		"m.private = false",
+		"m.cancelled = false",
+		"m.start_at - interval '30 minutes' > NOW()",
+	}
+	if filter.Sport != "" {
+		where = append(where, "m.sport = "+arg(filter.Sport))
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "m.start_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "m.start_at < "+arg(filter.To))
+	}
+	if filter.Location != "" {
+		pattern := "'%' || " + arg(escapeLike(filter.Location)) + " || '%'"
+		where = append(where, fmt.Sprintf("(m.location ILIKE %[1]s OR v.name ILIKE %[1]s OR v.address ILIKE %[1]s)", pattern))
+	}
+	distance := "NULL::float8"
+	if filter.Near != nil {
+		distance = fmt.Sprintf(distanceExpr, arg(filter.Near.Latitude), arg(filter.Near.Longitude))
+		radius := filter.RadiusKm
+		if radius <= 0 {
+			radius = defaultSearchRadiusKm
+		}
+		where = append(where, "v.latitude IS NOT NULL", distance+" <= "+arg(radius))
+	}
+	if filter.TeamSize > 0 {
+		where = append(where, "m.team_size = "+arg(filter.TeamSize))
+	}
+	if filter.Gender != enum.GenderAny {
+		where = append(where, "m.gender = "+arg(filter.Gender))
+	}
+	if p := filter.Eligible; p != nil {
+		level := arg(p.Level)
+		where = append(where,
+			"(m.gender = '' OR m.gender = "+arg(p.Gender)+")",
+			fmt.Sprintf("(m.min_level IS NULL OR m.min_level <= %[1]s) AND (m.max_level IS NULL OR m.max_level >= %[1]s)", level))
+		if age, ok := p.Age(time.Now()); ok {
+			where = append(where, fmt.Sprintf("(m.min_age IS NULL OR m.min_age <= %[1]s) AND (m.max_age IS NULL OR m.max_age >= %[1]s)", arg(age)))
+		} else {
+			where = append(where, "m.min_age IS NULL AND m.max_age IS NULL")
+		}
+	}
+	having := []string{"true"}
+	if filter.MinFreePlaces > 0 {
+		having = append(having, "m.team_count * m.team_size - count(tm.member_id) >= "+arg(filter.MinFreePlaces))
+	}
+	order := "m.start_at ASC"
+	if filter.Descending {
+		order = "m.start_at DESC"
+	}
+	if filter.Near != nil {
+		order = "distance ASC, " + order
+	}
+	limit, offset := "ALL", "0"
+	if filter.MaxPrice == 0 {
+		if filter.Limit > 0 {
+			limit = arg(filter.Limit)
+		}
+		offset = arg(filter.Offset)
+	}
+
+	var matches []*entity.Match
+	stmt := fmt.Sprintf(searchMatchesStmt, distance, strings.Join(where, " AND "), strings.Join(having, " AND "), order, limit, offset)
+	if err := pgxscan.Select(ctx, r.pool, &matches, stmt, args...); err != nil {
+		return nil, err
+	}
+	if filter.MaxPrice == 0 {
+		return matches, nil
+	}
+	affordable := matches[:0]
+	for _, m := range matches {
+		if m.JoinPrice() <= filter.MaxPrice {
+			affordable = append(affordable, m)
+		}
+	}
+	return page(affordable, filter.Offset, filter.Limit), nil
+}
+
+// page returns the matches from offset on, at most limit of them unless
+// limit is zero.
+func page(matches []*entity.Match, offset, limit int) []*entity.Match {
+	if offset >= len(matches) {
+		return nil
+	}
+	matches = matches[offset:]
+	if limit > 0 && limit < len(matches) {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,48 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	sportColumns  = `code, emoji, names, team_sizes, duration_minutes, team_count, position`
+	getSportsStmt = `SELECT ` + sportColumns + ` FROM sports ORDER BY position, code;`
+	getSportStmt  = `SELECT ` + sportColumns + ` FROM sports WHERE code = $1;`
+	saveSportStmt = `INSERT INTO sports(code, emoji, names, team_sizes, duration_minutes, team_count, position)
+						VALUES($1, $2, $3, $4, $5, $6, COALESCE((SELECT MAX(position) + 1 FROM sports), 1))
+						ON CONFLICT (code) DO UPDATE SET emoji = EXCLUDED.emoji, names = EXCLUDED.names,
+							team_sizes = EXCLUDED.team_sizes, duration_minutes = EXCLUDED.duration_minutes,
+							team_count = EXCLUDED.team_count;`
+)
+
+// GetSports returns the sports in the order they are offered in.
+func (r *repository) GetSports(ctx context.Context) ([]*entity.Sport, error) {
+	var sports []*entity.Sport
+	if err := pgxscan.Select(ctx, r.pool, &sports, getSportsStmt); err != nil {
+		return nil, err
+	}
+	return sports, nil
+}
+
+func (r *repository) GetSport(ctx context.Context, code enum.SportType) (*entity.Sport, error) {
+	sport := &entity.Sport{}
+	if err := pgxscan.Get(ctx, r.pool, sport, getSportStmt, code); err != nil {
+		return nil, err
+	}
+	return sport, nil
+}
+
+// SaveSport adds the sport to the end of the list or updates the one with
+// the same code in place.
+func (r *repository) SaveSport(ctx context.Context, sport *entity.Sport) error {
+	_, err := r.pool.Exec(ctx, saveSportStmt, sport.Code, sport.Emoji, sport.Names, sport.TeamSizes,
+		sport.DurationMinutes, sport.TeamCount)
+	if err != nil {
+		return err
+	}
+	return nil
+}
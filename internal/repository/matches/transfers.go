@@ -0,0 +1,99 @@
+package matches
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	createSpotTransferStmt = `INSERT INTO spot_transfers(match_id, from_user_id, to_user_id) VALUES($1, $2, $3)
+								RETURNING id;`
+	getSpotTransferStmt  = `SELECT id, match_id, from_user_id, to_user_id, status FROM spot_transfers WHERE id = $1;`
+	lockSpotTransferStmt = `SELECT id, match_id, from_user_id, to_user_id, status FROM spot_transfers
+								WHERE id = $1 FOR UPDATE;`
+	setSpotTransferStatusStmt = `UPDATE spot_transfers SET status = $2 WHERE id = $1;`
+	// the paid flag stays with the spot, confirmation is up to the new player
+	transferTeamMemberStmt = `UPDATE team_members tm SET member_id = $3, confirmed = false, attended = NULL
+								FROM teams t
+								WHERE t.id = tm.team_id AND t.match_id = $1 AND tm.member_id = $2
+								AND NOT EXISTS (
+									SELECT 1 FROM team_members o JOIN teams ot ON ot.id = o.team_id
+									WHERE ot.match_id = $1 AND o.member_id = $3
+								);`
+)
+
+func (r *repository) CreateSpotTransfer(ctx context.Context, transfer *entity.SpotTransfer) (*entity.SpotTransfer, error) {
+	var id int64
+	if err := r.pool.QueryRow(ctx, createSpotTransferStmt, transfer.MatchID, transfer.FromUserID, transfer.ToUserID).Scan(&id); err != nil {
+		return nil, err
+	}
+	transfer.ID = id
+	transfer.Status = enum.TransferStatusPending
+	return transfer, nil
+}
+
+func (r *repository) GetSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error) {
+	var transfer entity.SpotTransfer
+	if err := pgxscan.Get(ctx, r.pool, &transfer, getSpotTransferStmt, id); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// AcceptSpotTransfer swaps the players in a single transaction, so the spot
+// can neither be lost nor taken twice. It fails with a Conflict error when
+// the offer was already answered, the sender has left the match or the
+// receiver has joined it in the meantime.
+func (r *repository) AcceptSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	var transfer entity.SpotTransfer
+	if err := pgxscan.Get(ctx, tx, &transfer, lockSpotTransferStmt, id); err != nil {
+		return nil, err
+	}
+	if transfer.Status != enum.TransferStatusPending {
+		return nil, errors.Conflict.Newf("spot transfer %d is %s", id, transfer.Status)
+	}
+	tag, err := tx.Exec(ctx, transferTeamMemberStmt, transfer.MatchID, transfer.FromUserID, transfer.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, errors.Conflict.Newf("spot transfer %d is no longer possible", id)
+	}
+	if _, err := tx.Exec(ctx, setSpotTransferStatusStmt, id, enum.TransferStatusAccepted); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, recordCancellationStmt, transfer.MatchID, transfer.FromUserID, false, 0, transfer.ToUserID); err != nil {
+		return nil, err
+	}
+	transfer.Status = enum.TransferStatusAccepted
+	return &transfer, tx.Commit(ctx)
+}
+
+func (r *repository) DeclineSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	var transfer entity.SpotTransfer
+	if err := pgxscan.Get(ctx, tx, &transfer, lockSpotTransferStmt, id); err != nil {
+		return nil, err
+	}
+	if transfer.Status != enum.TransferStatusPending {
+		return nil, errors.Conflict.Newf("spot transfer %d is %s", id, transfer.Status)
+	}
+	if _, err := tx.Exec(ctx, setSpotTransferStatusStmt, id, enum.TransferStatusDeclined); err != nil {
+		return nil, err
+	}
+	transfer.Status = enum.TransferStatusDeclined
+	return &transfer, tx.Commit(ctx)
+}
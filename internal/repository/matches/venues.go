@@ -0,0 +1,139 @@
+package matches
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const (
+	venueColumns    = `v.id, v.name, v.address, v.latitude, v.longitude, v.sports, v.default_rent, v.photo_file_id, v.created_by, v.conflict_policy`
+	createVenueStmt = `INSERT INTO venues(name, address, latitude, longitude, sports, default_rent, photo_file_id, created_by)
+						VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+						RETURNING id;`
+	getVenueByIDStmt     = `SELECT ` + venueColumns + ` FROM venues v WHERE v.id = $1;`
+	getVenueByNameStmt   = `SELECT ` + venueColumns + ` FROM venues v WHERE lower(v.name) = lower($1);`
+	getVenuesBySportStmt = `SELECT ` + venueColumns + `
+								FROM venues v
+								LEFT JOIN matches m ON m.venue_id = v.id
+								WHERE $1 = ANY(v.sports)
+								GROUP BY v.id
+								ORDER BY count(m.id) DESC, v.name
+								LIMIT $2;`
+	getVenuesByCreatorStmt = `SELECT ` + venueColumns + ` FROM venues v WHERE v.created_by = $1 ORDER BY v.name;`
+	setVenueLocationStmt   = `UPDATE venues SET latitude = $2, longitude = $3, address = CASE WHEN $4 = '' THEN address ELSE $4 END
+								WHERE id = $1;`
+	setVenuePhotoStmt          = `UPDATE venues SET photo_file_id = $2 WHERE id = $1;`
+	setVenueDefaultRentStmt    = `UPDATE venues SET default_rent = $2 WHERE id = $1 AND default_rent = 0;`
+	setVenueConflictPolicyStmt = `UPDATE venues SET conflict_policy = $2 WHERE id = $1;`
+	getVenueMatchesStmt        = `SELECT id, sport, organizer_id, location, team_size, team_count, rent, start_at, finish_at, venue_id
+								FROM matches
+								WHERE venue_id = $1 AND cancelled = false AND id <> $4 AND start_at < $3 AND finish_at > $2
+								ORDER BY start_at;`
+	lockMatchVenueStmt = `SELECT conflict_policy FROM venues
+								WHERE id = (SELECT venue_id FROM matches WHERE id = $1)
+								FOR UPDATE;`
+	venueBusyStmt = `SELECT EXISTS (
+								SELECT 1 FROM matches o JOIN matches m ON m.venue_id = o.venue_id
+								WHERE m.id = $1 AND o.id <> $1 AND o.cancelled = false AND o.start_at < $3 AND o.finish_at > $2
+							);`
+	addVenueSportStmt = `UPDATE venues SET sports = array_append(sports, $2::text) WHERE id = $1 AND NOT ($2::text = ANY(sports));`
+)
+
+func (r *repository) CreateVenue(ctx context.Context, venue *entity.Venue) (*entity.Venue, error) {
+	var id int64
+	if err := r.pool.QueryRow(ctx, createVenueStmt, venue.Name, venue.Address,
+		venue.Latitude, venue.Longitude, venue.Sports, venue.DefaultRent,
+		venue.PhotoFileID, venue.CreatedBy).Scan(&id); err != nil {
+		return nil, err
+	}
+	venue.ID = id
+	return venue, nil
+}
+
+func (r *repository) GetVenue(ctx context.Context, id int64) (*entity.Venue, error) {
+	var venue entity.Venue
+	if err := pgxscan.Get(ctx, r.pool, &venue, getVenueByIDStmt, id); err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *repository) GetVenueByName(ctx context.Context, name string) (*entity.Venue, error) {
+	var venue entity.Venue
+	if err := pgxscan.Get(ctx, r.pool, &venue, getVenueByNameStmt, name); err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// GetVenuesBySport returns the venues the sport is played at, most used first.
+func (r *repository) GetVenuesBySport(ctx context.Context, sport string, limit int) ([]*entity.Venue, error) {
+	var venues []*entity.Venue
+	if err := pgxscan.Select(ctx, r.pool, &venues, getVenuesBySportStmt, sport, limit); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (r *repository) GetVenuesByCreator(ctx context.Context, userID int64) ([]*entity.Venue, error) {
+	var venues []*entity.Venue
+	if err := pgxscan.Select(ctx, r.pool, &venues, getVenuesByCreatorStmt, userID); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (r *repository) SetVenueLocation(ctx context.Context, id int64, point entity.GeoPoint, address string) error {
+	_, err := r.pool.Exec(ctx, setVenueLocationStmt, id, point.Latitude, point.Longitude, address)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) SetVenuePhoto(ctx context.Context, id int64, fileID string) error {
+	_, err := r.pool.Exec(ctx, setVenuePhotoStmt, id, fileID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetVenueDefaultRent remembers the rent for venues that do not have one yet.
+func (r *repository) SetVenueDefaultRent(ctx context.Context, id, rent int64) error {
+	_, err := r.pool.Exec(ctx, setVenueDefaultRentStmt, id, rent)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) AddVenueSport(ctx context.Context, id int64, sport string) error {
+	_, err := r.pool.Exec(ctx, addVenueSportStmt, id, sport)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *repository) SetVenueConflictPolicy(ctx context.Context, id int64, policy enum.ConflictPolicy) error {
+	_, err := r.pool.Exec(ctx, setVenueConflictPolicyStmt, id, policy)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetVenueMatches returns the matches at the venue overlapping [from, to),
+// leaving out the match with excludeID so that a match never conflicts with itself.
+func (r *repository) GetVenueMatches(ctx context.Context, venueID int64, from, to time.Time, excludeID int64) ([]*entity.Match, error) {
+	var matches []*entity.Match
+	if err := pgxscan.Select(ctx, r.pool, &matches, getVenueMatchesStmt, venueID, from, to, excludeID); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
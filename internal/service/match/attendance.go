@@ -0,0 +1,83 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+// SignUpToMatch adds the player to the team if it has a free place, unless
+// the match requires a higher reliability than the player has or the
+// player doesn't meet its eligibility rules.
+func (s *service) SignUpToMatch(ctx context.Context, userID, teamID int64) error {
+	matchID, err := s.matchesRepository.GetMatchIDByTeamID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if match.TeamOf(userID) != nil {
+		return errors.Conflict.Newf("user %d already plays in match %d", userID, matchID)
+	}
+	if !match.Team(teamID).HasRoom() {
+		return errors.Conflict.Newf("team %d is full", teamID)
+	}
+	if err := s.ensureCanJoin(ctx, match, userID); err != nil {
+		return err
+	}
+	return s.matchesRepository.SignUpToMatch(ctx, userID, teamID)
+}
+
+// ensureCanJoin fails with a Forbidden error when the match requires a
+// higher reliability than the player has or the player doesn't meet its
+// eligibility rules.
+func (s *service) ensureCanJoin(ctx context.Context, match *entity.Match, userID int64) error {
+	if match.MinReliability != nil {
+		reliability, err := s.matchesRepository.GetReliability(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if score := reliability.Score(); score < *match.MinReliability {
+			return errors.Forbidden.Newf("reliability %d%% is below %d%% required by match %d", score, *match.MinReliability, match.ID)
+		}
+	}
+	if match.Eligibility.IsSet() {
+		profile, err := s.matchesRepository.GetProfile(ctx, userID, match.Type)
+		if err != nil {
+			return err
+		}
+		if rule := match.Eligibility.Unmet(profile, time.Now()); rule != "" {
+			return errors.Forbidden.Newf("user %d doesn't meet the %s rule of match %d", userID, rule, match.ID)
+		}
+	}
+	return nil
+}
+
+// SetAttendance records whether the player showed up, once the match is
+// over: a no-show counts against their reliability.
+func (s *service) SetAttendance(ctx context.Context, matchID, userID int64, attended bool) error {
+	match, err := s.matchesRepository.GetMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if time.Now().Before(match.FinishAt) {
+		return errors.Invalid.Newf("match %d is not over yet", matchID)
+	}
+	return s.matchesRepository.SetAttendance(ctx, matchID, userID, attended)
+}
+
+func (s *service) GetReliability(ctx context.Context, userID int64) (*entity.Reliability, error) {
+	return s.matchesRepository.GetReliability(ctx, userID)
+}
+
+// SetMinReliability sets the sign-up threshold of the match. Zero removes it.
+func (s *service) SetMinReliability(ctx context.Context, matchID, minReliability int64) error {
+	if minReliability <= 0 {
+		return s.matchesRepository.SetMinReliability(ctx, matchID, nil)
+	}
+	return s.matchesRepository.SetMinReliability(ctx, matchID, &minReliability)
+}
@@ -0,0 +1,157 @@
+package match
+
+import (
+	"context"
+	"sort"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+)
+
+// Organizer-assigned player levels, on the same scale as the default rating.
+const (
+	RatingBeginner     int64 = 800
+	RatingIntermediate int64 = 1000
+	RatingAdvanced     int64 = 1200
+	RatingPro          int64 = 1400
+)
+
+const maxBalanceIterations = 100
+
+func (s *service) SetPlayerRating(ctx context.Context, userID int64, sport enum.SportType, rating int64) error {
+	return s.matchesRepository.SetPlayerRating(ctx, userID, sport, rating)
+}
+
+// PreviewBalancedTeams proposes a distribution of the match players that
+// keeps the teams equally sized and their total ratings as close as possible.
+func (s *service) PreviewBalancedTeams(ctx context.Context, matchID int64) (*entity.TeamAssignment, error) {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	return balanceTeams(match.Teams), nil
+}
+
+// ApplyBalancedTeams moves the players as proposed by PreviewBalancedTeams.
+// The proposal is recomputed so that sign-ups made after the preview are
+// taken into account.
+func (s *service) ApplyBalancedTeams(ctx context.Context, matchID int64) (*entity.TeamAssignment, error) {
+	assignment, err := s.PreviewBalancedTeams(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.matchesRepository.MoveTeamMembers(ctx, assignment.Moves); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func balanceTeams(teams []*entity.Team) *entity.TeamAssignment {
+	var players []*entity.User
+	current := make(map[int64]int64)
+	for _, team := range teams {
+		for _, member := range team.Members {
+			players = append(players, member)
+			current[member.ID] = team.ID
+		}
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Rating != players[j].Rating {
+			return players[i].Rating > players[j].Rating
+		}
+		return players[i].ID < players[j].ID
+	})
+
+	groups := make([][]*entity.User, len(teams))
+	sums := make([]int64, len(teams))
+	for _, player := range players {
+		best := 0
+		for i := range groups {
+			if len(groups[i]) < len(groups[best]) ||
+				(len(groups[i]) == len(groups[best]) && sums[i] < sums[best]) {
+				best = i
+			}
+		}
+		groups[best] = append(groups[best], player)
+		sums[best] += player.Rating
+	}
+	improveBySwaps(groups, sums)
+
+	assignment := &entity.TeamAssignment{}
+	for i, team := range matchGroupsToTeams(groups, teams, current) {
+		proposed := &entity.Team{ID: team.ID, Name: team.Name, Size: team.Size, Members: groups[i]}
+		assignment.Teams = append(assignment.Teams, proposed)
+		for _, member := range groups[i] {
+			if current[member.ID] != team.ID {
+				assignment.Moves = append(assignment.Moves, &entity.TeamMove{
+					User:       member,
+					FromTeamID: current[member.ID],
+					ToTeamID:   team.ID,
+				})
+			}
+		}
+	}
+	return assignment
+}
+
+// improveBySwaps swaps players between pairs of groups while it narrows the
+// gap between their rating sums.
+func improveBySwaps(groups [][]*entity.User, sums []int64) {
+	for iteration := 0; iteration < maxBalanceIterations; iteration++ {
+		improved := false
+		for i := range groups {
+			for j := i + 1; j < len(groups); j++ {
+				for a := range groups[i] {
+					for b := range groups[j] {
+						delta := groups[i][a].Rating - groups[j][b].Rating
+						before := abs(sums[i] - sums[j])
+						after := abs((sums[i] - delta) - (sums[j] + delta))
+						if after < before {
+							groups[i][a], groups[j][b] = groups[j][b], groups[i][a]
+							sums[i] -= delta
+							sums[j] += delta
+							improved = true
+						}
+					}
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// matchGroupsToTeams gives each group the team most of its players are
+// already in, so that as few players as possible have to move.
+func matchGroupsToTeams(groups [][]*entity.User, teams []*entity.Team, current map[int64]int64) []*entity.Team {
+	result := make([]*entity.Team, len(groups))
+	taken := make(map[int64]bool)
+	for i, group := range groups {
+		best, bestOverlap := -1, -1
+		for j, team := range teams {
+			if taken[team.ID] {
+				continue
+			}
+			overlap := 0
+			for _, member := range group {
+				if current[member.ID] == team.ID {
+					overlap++
+				}
+			}
+			if overlap > bestOverlap {
+				best, bestOverlap = j, overlap
+			}
+		}
+		taken[teams[best].ID] = true
+		result[i] = teams[best]
+	}
+	return result
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,35 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+)
+
+// SignOutMatch removes the player from the match and keeps the cancellation
+// in the player's history. A confirmed player leaving after the cancel
+// deadline is liable for their share.
+func (s *service) SignOutMatch(ctx context.Context, userID, matchID int64) (*entity.Cancellation, error) {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	late := match.LateCancel(userID, time.Now())
+	if err := s.matchesRepository.DeleteTeamMember(ctx, matchID, userID); err != nil {
+		return nil, err
+	}
+	cancellation := &entity.Cancellation{MatchID: matchID, UserID: userID}
+	if late {
+		cancellation.Late = true
+		cancellation.LiableAmount = match.PriceFor(userID)
+	}
+	if err := s.matchesRepository.RecordCancellation(ctx, cancellation); err != nil {
+		return nil, err
+	}
+	return cancellation, nil
+}
+
+func (s *service) SetCancelWindow(ctx context.Context, matchID, hours int64) error {
+	return s.matchesRepository.SetCancelWindow(ctx, matchID, hours)
+}
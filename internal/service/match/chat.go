@@ -0,0 +1,80 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/samber/lo"
+)
+
+const (
+	// chatRateLimit is how many chat messages a player can send within
+	// chatRateWindow, across all matches.
+	chatRateLimit  = 5
+	chatRateWindow = time.Minute
+)
+
+// SendChatMessage stores the message a member or the organizer sent to the
+// match and returns who it has to be delivered to: everyone else in the
+// match who hasn't muted its chat.
+func (s *service) SendChatMessage(ctx context.Context, message *entity.ChatMessage) ([]*entity.User, error) {
+	match, err := s.GetMatchByMatchID(ctx, message.MatchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.OrganizerID != message.SenderID && match.TeamOf(message.SenderID) == nil {
+		return nil, errors.Forbidden.Newf("user %d is not in match %d", message.SenderID, message.MatchID)
+	}
+	count, err := s.matchesRepository.CountChatMessages(ctx, message.SenderID, time.Now().Add(-chatRateWindow))
+	if err != nil {
+		return nil, err
+	}
+	if count >= chatRateLimit {
+		return nil, errors.Conflict.Newf("user %d sent %d chat messages in the last %s", message.SenderID, count, chatRateWindow)
+	}
+	recipients := match.Players()
+	if match.TeamOf(match.OrganizerID) == nil {
+		organizer, err := s.matchesRepository.GetUserByID(ctx, match.OrganizerID)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, organizer)
+	}
+	muted, err := s.matchesRepository.GetChatMutedUsers(ctx, message.MatchID)
+	if err != nil {
+		return nil, err
+	}
+	recipients = lo.Filter(recipients, func(u *entity.User, _ int) bool {
+		return u.ID != message.SenderID && !lo.Contains(muted, u.ID)
+	})
+	if err := s.matchesRepository.CreateChatMessage(ctx, message); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+func (s *service) AddChatCopy(ctx context.Context, c *entity.ChatCopy) error {
+	return s.matchesRepository.AddChatCopy(ctx, c)
+}
+
+func (s *service) GetChatCopies(ctx context.Context, messageID int64) ([]*entity.ChatCopy, error) {
+	return s.matchesRepository.GetChatCopies(ctx, messageID)
+}
+
+func (s *service) GetChatMessageByCopy(ctx context.Context, chatID int64, telegramMessageID int) (*entity.ChatMessage, error) {
+	return s.matchesRepository.GetChatMessageByCopy(ctx, chatID, telegramMessageID)
+}
+
+func (s *service) SetChatMuted(ctx context.Context, matchID, userID int64, muted bool) error {
+	return s.matchesRepository.SetChatMuted(ctx, matchID, userID, muted)
+}
+
+func (s *service) IsChatMuted(ctx context.Context, matchID, userID int64) (bool, error) {
+	muted, err := s.matchesRepository.GetChatMutedUsers(ctx, matchID)
+	if err != nil {
+		return false, err
+	}
+	return lo.Contains(muted, userID), nil
+}
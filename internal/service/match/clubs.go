@@ -0,0 +1,100 @@
+package match
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/samber/lo"
+)
+
+const maxTeamNameLength = 32
+
+// RenameTeam names the team and keeps the name as a club of the organizer.
+// Reusing a club name brings back the club's badge.
+func (s *service) RenameTeam(ctx context.Context, organizerID, teamID int64, name string) (*entity.Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxTeamNameLength {
+		return nil, errors.Invalid.Newf("team name must be 1-%d characters long", maxTeamNameLength)
+	}
+	team, err := s.getTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	club, err := s.matchesRepository.UpsertClub(ctx, &entity.Club{OwnerID: organizerID, Name: name, Emoji: team.Emoji})
+	if err != nil {
+		return nil, err
+	}
+	return s.applyClub(ctx, team, club)
+}
+
+// SetTeamBadge changes the team badge, and the badge of its club if any.
+func (s *service) SetTeamBadge(ctx context.Context, teamID int64, emoji string) (*entity.Team, error) {
+	if !lo.Contains(s.badges, emoji) {
+		return nil, errors.Invalid.Newf("unknown badge %q", emoji)
+	}
+	team, err := s.getTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	team.Emoji = emoji
+	if err := s.matchesRepository.UpdateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	if team.ClubID != nil {
+		if err := s.matchesRepository.SetClubEmoji(ctx, *team.ClubID, emoji); err != nil {
+			return nil, err
+		}
+	}
+	return team, nil
+}
+
+// ApplyClub gives the team the name and badge of one of the organizer's clubs.
+func (s *service) ApplyClub(ctx context.Context, organizerID, teamID, clubID int64) (*entity.Team, error) {
+	club, err := s.matchesRepository.GetClub(ctx, clubID)
+	if err != nil {
+		return nil, err
+	}
+	if club.OwnerID != organizerID {
+		return nil, errors.Forbidden.Newf("club %d does not belong to user %d", clubID, organizerID)
+	}
+	team, err := s.getTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return s.applyClub(ctx, team, club)
+}
+
+func (s *service) GetClubs(ctx context.Context, ownerID int64) ([]*entity.Club, error) {
+	return s.matchesRepository.GetClubsByOwner(ctx, ownerID)
+}
+
+func (s *service) applyClub(ctx context.Context, team *entity.Team, club *entity.Club) (*entity.Team, error) {
+	team.Name = club.Name
+	if club.Emoji != "" {
+		team.Emoji = club.Emoji
+	}
+	team.ClubID = &club.ID
+	if err := s.matchesRepository.UpdateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *service) getTeam(ctx context.Context, teamID int64) (*entity.Team, error) {
+	matchID, err := s.matchesRepository.GetMatchIDByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.matchesRepository.GetTeamsByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		if team.ID == teamID {
+			return team, nil
+		}
+	}
+	return nil, errors.Invalid.Newf("team %d not found", teamID)
+}
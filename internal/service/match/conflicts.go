@@ -0,0 +1,108 @@
+package match
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+)
+
+const (
+	freeSlotsCount        = 3
+	freeSlotsSearchWindow = 12 * time.Hour
+	freeSlotStep          = time.Hour
+)
+
+// CheckVenueConflicts looks for matches overlapping [StartAt, FinishAt) of
+// the match at the same venue. It returns nil when the venue is free.
+func (s *service) CheckVenueConflicts(ctx context.Context, match *entity.Match) (*entity.VenueConflict, error) {
+	if match.VenueID == nil {
+		return nil, nil
+	}
+	overlapping, err := s.matchesRepository.GetVenueMatches(ctx, *match.VenueID, match.StartAt, match.FinishAt, match.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) == 0 {
+		return nil, nil
+	}
+	venue, err := s.matchesRepository.GetVenue(ctx, *match.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := s.GetFreeSlots(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.VenueConflict{
+		Policy:    venue.ConflictPolicy,
+		Matches:   overlapping,
+		FreeSlots: slots,
+	}, nil
+}
+
+// RescheduleMatch moves the match to startAt keeping its duration. Venues
+// that block overlapping bookings make it fail with errors.Conflict, the
+// check being made by the repository together with the move.
+func (s *service) RescheduleMatch(ctx context.Context, matchID int64, startAt time.Time) (*entity.Match, error) {
+	match, err := s.matchesRepository.GetMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	duration := match.FinishAt.Sub(match.StartAt)
+	match.StartAt, match.FinishAt = startAt, startAt.Add(duration)
+	if err := s.matchesRepository.RescheduleMatch(ctx, matchID, match.StartAt, match.FinishAt); err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// GetFreeSlots returns the nearest starts around the match time at which its
+// venue is free for the whole duration of the match.
+func (s *service) GetFreeSlots(ctx context.Context, match *entity.Match) ([]time.Time, error) {
+	duration := match.FinishAt.Sub(match.StartAt)
+	if match.VenueID == nil {
+		return nearestFreeSlots(nil, match.StartAt, duration, time.Now(), freeSlotsCount), nil
+	}
+	busy, err := s.matchesRepository.GetVenueMatches(ctx, *match.VenueID,
+		match.StartAt.Add(-freeSlotsSearchWindow), match.FinishAt.Add(freeSlotsSearchWindow), match.ID)
+	if err != nil {
+		return nil, err
+	}
+	return nearestFreeSlots(busy, match.StartAt, duration, time.Now(), freeSlotsCount), nil
+}
+
+func (s *service) SetVenueConflictPolicy(ctx context.Context, venueID int64, policy enum.ConflictPolicy) error {
+	return s.matchesRepository.SetVenueConflictPolicy(ctx, venueID, policy)
+}
+
+// nearestFreeSlots walks away from the desired start hour by hour, in both
+// directions, and returns up to n starts that overlap none of the busy
+// matches, sorted by time.
+func nearestFreeSlots(busy []*entity.Match, desired time.Time, duration time.Duration, now time.Time, n int) []time.Time {
+	var slots []time.Time
+	steps := int(freeSlotsSearchWindow / freeSlotStep)
+	for i := 1; i <= steps && len(slots) < n; i++ {
+		for _, start := range []time.Time{desired.Add(time.Duration(i) * freeSlotStep), desired.Add(-time.Duration(i) * freeSlotStep)} {
+			if len(slots) == n || !start.After(now) || overlaps(busy, start, start.Add(duration)) {
+				continue
+			}
+			slots = append(slots, start)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Before(slots[j])
+	})
+	return slots
+}
+
+func overlaps(busy []*entity.Match, start, finish time.Time) bool {
+	for _, m := range busy {
+		if m.StartAt.Before(finish) && m.FinishAt.After(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+const (
+	minPlayerAge = 5
+	maxPlayerAge = 100
+)
+
+// SetEligibility replaces the restrictions on who can sign up for the match.
+func (s *service) SetEligibility(ctx context.Context, matchID int64, e *entity.Eligibility) error {
+	for _, level := range []*int64{e.MinLevel, e.MaxLevel} {
+		if level != nil && (*level < RatingBeginner || *level > RatingPro) {
+			return errors.Invalid.Newf("level must be between %d and %d, got %d", RatingBeginner, RatingPro, *level)
+		}
+	}
+	if e.MinLevel != nil && e.MaxLevel != nil && *e.MinLevel > *e.MaxLevel {
+		return errors.Invalid.Newf("min level %d is above max level %d", *e.MinLevel, *e.MaxLevel)
+	}
+	if err := validateGender(e.Gender); err != nil {
+		return err
+	}
+	for _, age := range []*int64{e.MinAge, e.MaxAge} {
+		if age != nil && (*age < minPlayerAge || *age > maxPlayerAge) {
+			return errors.Invalid.Newf("age must be between %d and %d, got %d", minPlayerAge, maxPlayerAge, *age)
+		}
+	}
+	if e.MinAge != nil && e.MaxAge != nil && *e.MinAge > *e.MaxAge {
+		return errors.Invalid.Newf("min age %d is above max age %d", *e.MinAge, *e.MaxAge)
+	}
+	return s.matchesRepository.SetEligibility(ctx, matchID, e)
+}
+
+func (s *service) GetProfile(ctx context.Context, userID int64, sport enum.SportType) (*entity.Profile, error) {
+	return s.matchesRepository.GetProfile(ctx, userID, sport)
+}
+
+func (s *service) SetGender(ctx context.Context, userID int64, gender enum.Gender) error {
+	if err := validateGender(gender); err != nil {
+		return err
+	}
+	return s.matchesRepository.SetGender(ctx, userID, gender)
+}
+
+func (s *service) SetBirthYear(ctx context.Context, userID, year int64) error {
+	now := int64(time.Now().Year())
+	if year < now-maxPlayerAge || year > now-minPlayerAge {
+		return errors.Invalid.Newf("birth year must be between %d and %d, got %d", now-maxPlayerAge, now-minPlayerAge, year)
+	}
+	return s.matchesRepository.SetBirthYear(ctx, userID, year)
+}
+
+func validateGender(gender enum.Gender) error {
+	switch gender {
+	case enum.GenderAny, enum.GenderFemale, enum.GenderMale:
+		return nil
+	default:
+		return errors.Invalid.Newf("unknown gender %q", gender)
+	}
+}
@@ -0,0 +1,68 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+func (s *service) AddExpense(ctx context.Context, matchID int64, category enum.ExpenseCategory, amount int64) error {
+	if amount <= 0 {
+		return errors.Invalid.Newf("expense amount must be positive, got %d", amount)
+	}
+	return s.matchesRepository.AddExpense(ctx, &entity.Expense{MatchID: matchID, Category: category, Amount: amount})
+}
+
+func (s *service) ClearExpenses(ctx context.Context, matchID int64) error {
+	return s.matchesRepository.DeleteExpenses(ctx, matchID)
+}
+
+// GetCostSplit divides the cost of the match between its attendees, or its
+// players while attendance has not been marked, in proportion to their
+// discounts, rounding the shares up, and compares it with what each of them
+// has paid. Absent players who paid are listed with no share.
+func (s *service) GetCostSplit(ctx context.Context, matchID int64) (*entity.CostSplit, error) {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := s.matchesRepository.GetExpenses(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	split := &entity.CostSplit{Match: match, Expenses: expenses}
+	rentRecorded := false
+	for _, expense := range expenses {
+		split.Total += expense.Amount
+		if expense.Category == enum.ExpenseCategoryRent {
+			rentRecorded = true
+		}
+	}
+	if !rentRecorded {
+		split.Total += match.Rent
+	}
+	attendees := match.Attendees()
+	if len(attendees) == 0 {
+		return split, nil
+	}
+	shares := match.SplitCost(split.Total, attendees)
+	for _, player := range match.Players() {
+		_, attended := shares[player.ID]
+		var paid int64
+		if player.Paid {
+			paid = player.PaidAmount
+		}
+		if !attended && paid == 0 {
+			continue
+		}
+		split.Balances = append(split.Balances, &entity.PlayerBalance{
+			User:    player,
+			Share:   shares[player.ID],
+			Paid:    paid,
+			Balance: paid - shares[player.ID],
+		})
+	}
+	return split, nil
+}
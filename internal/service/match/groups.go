@@ -0,0 +1,55 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+)
+
+func (s *service) LinkMatchChat(ctx context.Context, chat *entity.MatchChat) error {
+	return s.matchesRepository.LinkMatchChat(ctx, chat)
+}
+
+// UnlinkMatchChat detaches the group from the match and returns the invite
+// links handed out for it, which have to be revoked.
+func (s *service) UnlinkMatchChat(ctx context.Context, matchID int64) ([]*entity.ChatInvite, error) {
+	invites, err := s.matchesRepository.GetChatInvites(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	for _, invite := range invites {
+		if err := s.matchesRepository.DeleteChatInvite(ctx, matchID, invite.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.matchesRepository.UnlinkMatchChat(ctx, matchID); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (s *service) GetMatchChat(ctx context.Context, matchID int64) (*entity.MatchChat, error) {
+	return s.matchesRepository.GetMatchChat(ctx, matchID)
+}
+
+func (s *service) SaveChatInvite(ctx context.Context, invite *entity.ChatInvite) error {
+	return s.matchesRepository.SaveChatInvite(ctx, invite)
+}
+
+func (s *service) GetChatInvite(ctx context.Context, matchID, userID int64) (*entity.ChatInvite, error) {
+	return s.matchesRepository.GetChatInvite(ctx, matchID, userID)
+}
+
+func (s *service) DeleteChatInvite(ctx context.Context, matchID, userID int64) error {
+	return s.matchesRepository.DeleteChatInvite(ctx, matchID, userID)
+}
+
+// KeepsMatchChat reports whether the player leaving the match still belongs
+// in its group because of another upcoming match linked to it.
+func (s *service) KeepsMatchChat(ctx context.Context, chat *entity.MatchChat, userID int64) (bool, error) {
+	count, err := s.matchesRepository.CountLinkedMatches(ctx, chat.ChatID, userID, chat.MatchID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
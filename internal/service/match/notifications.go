@@ -0,0 +1,75 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/samber/lo"
+)
+
+// digestInterval is how long notifications of users who chose the digest
+// are collected before being delivered.
+const digestInterval = time.Hour
+
+func (s *service) GetNotificationSettings(ctx context.Context, userID int64) (*entity.NotificationSettings, error) {
+	return s.matchesRepository.GetNotificationSettings(ctx, userID)
+}
+
+func (s *service) SaveNotificationSettings(ctx context.Context, settings *entity.NotificationSettings) error {
+	for _, kind := range settings.Muted {
+		if !lo.Contains(enum.NotificationKinds, enum.NotificationKind(kind)) {
+			return errors.Invalid.Newf("unknown notification kind %q", kind)
+		}
+	}
+	if (settings.QuietFrom == nil) != (settings.QuietTo == nil) {
+		return errors.Invalid.New("quiet hours need both a start and an end")
+	}
+	if settings.QuietFrom != nil {
+		from, to := *settings.QuietFrom, *settings.QuietTo
+		if from < 0 || from > 23 || to < 0 || to > 23 || from == to {
+			return errors.Invalid.Newf("invalid quiet hours %d-%d", from, to)
+		}
+	}
+	if settings.UTCOffset < -12 || settings.UTCOffset > 14 {
+		return errors.Invalid.Newf("invalid UTC offset %d", settings.UTCOffset)
+	}
+	return s.matchesRepository.SaveNotificationSettings(ctx, settings)
+}
+
+func (s *service) HoldNotification(ctx context.Context, n *entity.PendingNotification) error {
+	return s.matchesRepository.HoldNotification(ctx, n)
+}
+
+// DueDigests returns the held notifications to deliver now: those of users
+// whose quiet hours are over, once the oldest of them has waited for the
+// digest interval when the user chose the digest.
+func (s *service) DueDigests(ctx context.Context, now time.Time) ([]*entity.Digest, error) {
+	pending, err := s.matchesRepository.GetPendingNotifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var digests []*entity.Digest
+	for _, notifications := range lo.GroupBy(pending, func(n *entity.PendingNotification) int64 { return n.UserID }) {
+		userID := notifications[0].UserID
+		settings, err := s.matchesRepository.GetNotificationSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if settings.Quiet(now) || settings.Digest && now.Sub(notifications[0].CreatedAt) < digestInterval {
+			continue
+		}
+		user, err := s.matchesRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, &entity.Digest{User: user, Notifications: notifications})
+	}
+	return digests, nil
+}
+
+func (s *service) DeletePendingNotifications(ctx context.Context, ids []int64) error {
+	return s.matchesRepository.DeletePendingNotifications(ctx, ids)
+}
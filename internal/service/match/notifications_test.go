@@ -0,0 +1,130 @@
+package match
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/repository/matches"
+)
+
+// notificationsRepository serves held notifications and settings from
+// memory, the other methods of the repository are not implemented.
+type notificationsRepository struct {
+	matches.Repository
+	pending  []*entity.PendingNotification
+	settings map[int64]*entity.NotificationSettings
+}
+
+func (r *notificationsRepository) GetPendingNotifications(context.Context) ([]*entity.PendingNotification, error) {
+	return r.pending, nil
+}
+
+func (r *notificationsRepository) GetNotificationSettings(_ context.Context, userID int64) (*entity.NotificationSettings, error) {
+	if settings, ok := r.settings[userID]; ok {
+		return settings, nil
+	}
+	return &entity.NotificationSettings{UserID: userID, UTCOffset: entity.DefaultUTCOffset}, nil
+}
+
+func (r *notificationsRepository) GetUserByID(_ context.Context, id int64) (*entity.User, error) {
+	return &entity.User{ID: id}, nil
+}
+
+func TestDueDigests(t *testing.T) {
+	// now is 14:30 in UTC, 19:30 in the default time zone.
+	now := time.Date(2023, time.July, 20, 14, 30, 0, 0, time.UTC)
+	hour := func(h int64) *int64 { return &h }
+	held := func(userID int64, ago time.Duration) *entity.PendingNotification {
+		return &entity.PendingNotification{UserID: userID, CreatedAt: now.Add(-ago)}
+	}
+	tests := []struct {
+		name     string
+		pending  []*entity.PendingNotification
+		settings []*entity.NotificationSettings
+		want     []int64
+	}{
+		{name: "nothing held"},
+		{
+			name:    "quiet hours over",
+			pending: []*entity.PendingNotification{held(1, time.Minute), held(1, time.Second)},
+			want:    []int64{1},
+		},
+		{
+			name:     "quiet hours in the user's time zone",
+			pending:  []*entity.PendingNotification{held(1, time.Minute)},
+			settings: []*entity.NotificationSettings{{UserID: 1, QuietFrom: hour(19), QuietTo: hour(7), UTCOffset: 5}},
+		},
+		{
+			name:     "quiet hours over in the user's time zone",
+			pending:  []*entity.PendingNotification{held(1, time.Minute)},
+			settings: []*entity.NotificationSettings{{UserID: 1, QuietFrom: hour(19), QuietTo: hour(7), UTCOffset: -3}},
+			want:     []int64{1},
+		},
+		{
+			name:     "digest still collecting",
+			pending:  []*entity.PendingNotification{held(1, 59*time.Minute), held(1, time.Minute)},
+			settings: []*entity.NotificationSettings{{UserID: 1, Digest: true}},
+		},
+		{
+			name:     "digest due",
+			pending:  []*entity.PendingNotification{held(1, time.Hour), held(1, time.Minute)},
+			settings: []*entity.NotificationSettings{{UserID: 1, Digest: true}},
+			want:     []int64{1},
+		},
+		{
+			name:    "only the users due",
+			pending: []*entity.PendingNotification{held(1, time.Minute), held(2, time.Minute), held(3, 2*time.Hour)},
+			settings: []*entity.NotificationSettings{
+				{UserID: 2, Digest: true},
+				{UserID: 3, Digest: true},
+			},
+			want: []int64{1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &notificationsRepository{pending: tt.pending, settings: map[int64]*entity.NotificationSettings{}}
+			for _, settings := range tt.settings {
+				repository.settings[settings.UserID] = settings
+			}
+			digests, err := New(repository, nil).DueDigests(context.Background(), now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int64
+			for _, digest := range digests {
+				got = append(got, digest.User.ID)
+				for _, n := range digest.Notifications {
+					if n.UserID != digest.User.ID {
+						t.Errorf("notification of user %d in the digest of user %d", n.UserID, digest.User.ID)
+					}
+				}
+				if want := countHeld(tt.pending, digest.User.ID); len(digest.Notifications) != want {
+					t.Errorf("digest of user %d has %d notifications, want %d", digest.User.ID, len(digest.Notifications), want)
+				}
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if len(got) != len(tt.want) {
+				t.Fatalf("digests for users %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("digests for users %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func countHeld(pending []*entity.PendingNotification, userID int64) int {
+	count := 0
+	for _, n := range pending {
+		if n.UserID == userID {
+			count++
+		}
+	}
+	return count
+}
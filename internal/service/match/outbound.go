@@ -0,0 +1,20 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+)
+
+func (s *service) CreateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error {
+	return s.matchesRepository.CreateOutboundMessage(ctx, m)
+}
+
+func (s *service) UpdateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error {
+	return s.matchesRepository.UpdateOutboundMessage(ctx, m)
+}
+
+func (s *service) GetDueOutboundMessages(ctx context.Context, now time.Time, limit int) ([]*entity.OutboundMessage, error) {
+	return s.matchesRepository.GetDueOutboundMessages(ctx, now, limit)
+}
@@ -0,0 +1,53 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+func (s *service) SetPricingModel(ctx context.Context, matchID int64, model enum.PricingModel) error {
+	switch model {
+	case enum.PricingModelCapacity, enum.PricingModelFixed, enum.PricingModelSignedUp,
+		enum.PricingModelAttended, enum.PricingModelFree:
+	default:
+		return errors.Invalid.Newf("unknown pricing model %q", model)
+	}
+	match, err := s.matchesRepository.GetMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	return s.matchesRepository.SetPricing(ctx, matchID, model, match.FixedPrice)
+}
+
+// SetFixedPrice switches the match to the fixed price per person.
+func (s *service) SetFixedPrice(ctx context.Context, matchID, price int64) error {
+	if price < 0 {
+		return errors.Invalid.Newf("price must not be negative, got %d", price)
+	}
+	return s.matchesRepository.SetPricing(ctx, matchID, enum.PricingModelFixed, price)
+}
+
+// SetDiscount sets the player's discount in percent, 100 exempts the player
+// and 0 removes the discount.
+func (s *service) SetDiscount(ctx context.Context, matchID, userID, percent int64) error {
+	if percent < 0 || percent > 100 {
+		return errors.Invalid.Newf("discount must be between 0 and 100, got %d", percent)
+	}
+	if percent == 0 {
+		return s.matchesRepository.DeleteDiscount(ctx, matchID, userID)
+	}
+	return s.matchesRepository.SetDiscount(ctx, &entity.Discount{MatchID: matchID, UserID: userID, Percent: percent})
+}
+
+// GetMemberPrice is what the player pays for the match with the pricing
+// model and their discount applied.
+func (s *service) GetMemberPrice(ctx context.Context, matchID, userID int64) (int64, error) {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return 0, err
+	}
+	return match.PriceFor(userID), nil
+}
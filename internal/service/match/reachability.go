@@ -0,0 +1,19 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+)
+
+func (s *service) MarkUnreachable(ctx context.Context, chatID int64, reason enum.UnreachableReason) error {
+	return s.matchesRepository.MarkUnreachable(ctx, chatID, reason)
+}
+
+func (s *service) GetUnreachableUsers(ctx context.Context, userIDs []int64) ([]*entity.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	return s.matchesRepository.GetUnreachableUsers(ctx, userIDs)
+}
@@ -0,0 +1,77 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+// reportsLimit is how many of the latest reports are shown on the match card.
+const reportsLimit = 5
+
+// AddToReport adds the text and attachments of a message to the report the
+// author is composing for the match. Only the organizer reports on a match.
+func (s *service) AddToReport(ctx context.Context, matchID, authorID int64, text string, attachments []*entity.Attachment) (*entity.Report, error) {
+	if err := s.ensureOrganizer(ctx, matchID, authorID); err != nil {
+		return nil, err
+	}
+	report, err := s.matchesRepository.GetDraftReport(ctx, matchID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if text != "" {
+		if err := s.matchesRepository.AppendReportText(ctx, report.ID, text); err != nil {
+			return nil, err
+		}
+	}
+	for _, attachment := range attachments {
+		attachment.ReportID = report.ID
+		if err := s.matchesRepository.AddReportAttachment(ctx, attachment); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// PublishReport makes the draft visible on the match card.
+func (s *service) PublishReport(ctx context.Context, reportID, authorID int64) (*entity.Report, error) {
+	report, err := s.matchesRepository.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.AuthorID != authorID {
+		return nil, errors.Forbidden.Newf("report %d is not written by user %d", reportID, authorID)
+	}
+	if err := s.ensureOrganizer(ctx, report.MatchID, authorID); err != nil {
+		return nil, err
+	}
+	if report.Published {
+		return nil, errors.Conflict.Newf("report %d is already published", reportID)
+	}
+	if report.Text == "" && len(report.Attachments) == 0 {
+		return nil, errors.Invalid.Newf("report %d is empty", reportID)
+	}
+	if err := s.matchesRepository.PublishReport(ctx, reportID); err != nil {
+		return nil, err
+	}
+	report.Published = true
+	return report, nil
+}
+
+func (s *service) GetMatchReports(ctx context.Context, matchID int64) ([]*entity.Report, error) {
+	return s.matchesRepository.GetMatchReports(ctx, matchID, reportsLimit)
+}
+
+// ensureOrganizer fails with a Forbidden error unless the user organizes
+// the match.
+func (s *service) ensureOrganizer(ctx context.Context, matchID, userID int64) error {
+	match, err := s.matchesRepository.GetMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if match.OrganizerID != userID {
+		return errors.Forbidden.Newf("user %d does not organize match %d", userID, matchID)
+	}
+	return nil
+}
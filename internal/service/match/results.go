@@ -0,0 +1,82 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+// RecordScore saves the score of every team of the match, in the order the
+// teams are listed on the match card, once the match is over.
+func (s *service) RecordScore(ctx context.Context, matchID int64, scores []int64) (*entity.Match, error) {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Before(match.FinishAt) {
+		return nil, errors.Invalid.Newf("match %d is not over yet", matchID)
+	}
+	if len(scores) != len(match.Teams) {
+		return nil, errors.Invalid.Newf("match %d has %d teams, got %d scores", matchID, len(match.Teams), len(scores))
+	}
+	byTeam := make(map[int64]int64, len(scores))
+	for i, team := range match.Teams {
+		if scores[i] < 0 {
+			return nil, errors.Invalid.Newf("negative score %d", scores[i])
+		}
+		byTeam[team.ID] = scores[i]
+		team.Score = &scores[i]
+	}
+	if err := s.matchesRepository.SetTeamScores(ctx, byTeam); err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+func (s *service) AddPlayerGoals(ctx context.Context, matchID, userID, delta int64) error {
+	if err := s.ensurePlayer(ctx, matchID, userID); err != nil {
+		return err
+	}
+	return s.matchesRepository.AddPlayerGoals(ctx, matchID, userID, delta)
+}
+
+func (s *service) SetMatchMVP(ctx context.Context, matchID, userID int64) error {
+	if err := s.ensurePlayer(ctx, matchID, userID); err != nil {
+		return err
+	}
+	return s.matchesRepository.SetMatchMVP(ctx, matchID, userID)
+}
+
+// ensurePlayer fails with errors.Invalid when the user isn't in a team of
+// the match, so that no stats are recorded for players who weren't there.
+func (s *service) ensurePlayer(ctx context.Context, matchID, userID int64) error {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if match.TeamOf(userID) == nil {
+		return errors.Invalid.Newf("user %d doesn't play in match %d", userID, matchID)
+	}
+	return nil
+}
+
+// TakeFinishedMatches returns the matches that have just finished and marks
+// them so that their organizers are asked for the score only once.
+func (s *service) TakeFinishedMatches(ctx context.Context) ([]*entity.Match, error) {
+	matches, err := s.matchesRepository.GetMatchesAwaitingResults(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		if err := s.matchesRepository.SetResultsRequested(ctx, match.ID); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+func (s *service) GetPlayerStats(ctx context.Context, userID int64) ([]*entity.PlayerStats, error) {
+	return s.matchesRepository.GetPlayerStats(ctx, userID)
+}
@@ -2,26 +2,105 @@ package match
 
 import (
 	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
 	"github.com/DarkhanShakhan/telegram-bot-template/internal/repository/matches"
 	"github.com/samber/lo"
 )
 
 type Service interface {
 	CreateMatch(ctx context.Context, match *entity.Match) (*entity.Match, error)
-	AddTeamMembers(ctx context.Context, teamID int64, members []string) error
+	AddTeamMembers(ctx context.Context, teamID, invitedBy int64, invitees []*entity.Invitee) (*entity.InviteResult, error)
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+	GetUserByID(ctx context.Context, id int64) (*entity.User, error)
 	GetMatchByMatchID(ctx context.Context, id int64) (*entity.Match, error)
 	GetMatchIDByTeamID(ctx context.Context, id int64) (int64, error)
-	CreateUser(ctx context.Context, user *entity.User) (*entity.User, error)
-	GetUsersByUsernames(ctx context.Context, members []string) []*entity.User
+	SyncUser(ctx context.Context, user *entity.User) (*entity.User, []*entity.Invitation, error)
+	SetLanguage(ctx context.Context, userID int64, lang i18n.Lang) error
 	GetOpenMatchesBySport(ctx context.Context, sport enum.SportType) ([]*entity.Match, error)
+	SearchMatches(ctx context.Context, filter *entity.MatchFilter) ([]*entity.Match, error)
+	GetMatchesNear(ctx context.Context, filter *entity.MatchFilter, point entity.GeoPoint, radiusKm float64) ([]*entity.Match, error)
+	GetVenue(ctx context.Context, id int64) (*entity.Venue, error)
+	GetVenuesBySport(ctx context.Context, sport enum.SportType) ([]*entity.Venue, error)
+	GetVenuesByCreator(ctx context.Context, userID int64) ([]*entity.Venue, error)
+	SaveVenue(ctx context.Context, venue *entity.Venue, sport enum.SportType) (*entity.Venue, error)
+	SetVenueLocation(ctx context.Context, id int64, point entity.GeoPoint, address string) error
+	SetVenuePhoto(ctx context.Context, id int64, fileID string) error
+	SetVenueConflictPolicy(ctx context.Context, venueID int64, policy enum.ConflictPolicy) error
+	CheckVenueConflicts(ctx context.Context, match *entity.Match) (*entity.VenueConflict, error)
+	RescheduleMatch(ctx context.Context, matchID int64, startAt time.Time) (*entity.Match, error)
+	GetFreeSlots(ctx context.Context, match *entity.Match) ([]time.Time, error)
+	SetPlayerRating(ctx context.Context, userID int64, sport enum.SportType, rating int64) error
+	PreviewBalancedTeams(ctx context.Context, matchID int64) (*entity.TeamAssignment, error)
+	ApplyBalancedTeams(ctx context.Context, matchID int64) (*entity.TeamAssignment, error)
+	RecordScore(ctx context.Context, matchID int64, scores []int64) (*entity.Match, error)
+	AddPlayerGoals(ctx context.Context, matchID, userID, delta int64) error
+	SetMatchMVP(ctx context.Context, matchID, userID int64) error
+	TakeFinishedMatches(ctx context.Context) ([]*entity.Match, error)
+	GetPlayerStats(ctx context.Context, userID int64) ([]*entity.PlayerStats, error)
+	SetAttendance(ctx context.Context, matchID, userID int64, attended bool) error
+	GetReliability(ctx context.Context, userID int64) (*entity.Reliability, error)
+	SetMinReliability(ctx context.Context, matchID, minReliability int64) error
 	SetMatchPaid(ctx context.Context, paid bool, memberID, matchID int64) error
 	SetMatchConfirmed(ctx context.Context, confirmed bool, memberID, teamID int64) error
 	SignUpToMatch(ctx context.Context, userID, matchID int64) error
-	SignOutMatch(ctx context.Context, userID, matchID int64) error
+	SignOutMatch(ctx context.Context, userID, matchID int64) (*entity.Cancellation, error)
+	RenameTeam(ctx context.Context, organizerID, teamID int64, name string) (*entity.Team, error)
+	SetTeamBadge(ctx context.Context, teamID int64, emoji string) (*entity.Team, error)
+	ApplyClub(ctx context.Context, organizerID, teamID, clubID int64) (*entity.Team, error)
+	GetClubs(ctx context.Context, ownerID int64) ([]*entity.Club, error)
+	AddExpense(ctx context.Context, matchID int64, category enum.ExpenseCategory, amount int64) error
+	ClearExpenses(ctx context.Context, matchID int64) error
+	GetCostSplit(ctx context.Context, matchID int64) (*entity.CostSplit, error)
+	AddToReport(ctx context.Context, matchID, authorID int64, text string, attachments []*entity.Attachment) (*entity.Report, error)
+	PublishReport(ctx context.Context, reportID, authorID int64) (*entity.Report, error)
+	GetMatchReports(ctx context.Context, matchID int64) ([]*entity.Report, error)
+	MoveToTeam(ctx context.Context, matchID, userID, teamID int64) (*entity.Match, error)
+	OfferSpot(ctx context.Context, matchID, userID int64, invitee *entity.Invitee) (*entity.SpotTransfer, *entity.User, error)
+	GetSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error)
+	AcceptSpotTransfer(ctx context.Context, id, userID int64) (*entity.SpotTransfer, error)
+	DeclineSpotTransfer(ctx context.Context, id, userID int64) (*entity.SpotTransfer, error)
+	SetCancelWindow(ctx context.Context, matchID, hours int64) error
+	SetPricingModel(ctx context.Context, matchID int64, model enum.PricingModel) error
+	SetFixedPrice(ctx context.Context, matchID, price int64) error
+	SetDiscount(ctx context.Context, matchID, userID, percent int64) error
+	GetMemberPrice(ctx context.Context, matchID, userID int64) (int64, error)
+	GetSports(ctx context.Context) ([]*entity.Sport, error)
+	GetSport(ctx context.Context, code enum.SportType) (*entity.Sport, error)
+	SaveSport(ctx context.Context, sport *entity.Sport) error
+	SetEligibility(ctx context.Context, matchID int64, e *entity.Eligibility) error
+	GetProfile(ctx context.Context, userID int64, sport enum.SportType) (*entity.Profile, error)
+	SetGender(ctx context.Context, userID int64, gender enum.Gender) error
+	SetBirthYear(ctx context.Context, userID, year int64) error
+	SendChatMessage(ctx context.Context, message *entity.ChatMessage) ([]*entity.User, error)
+	AddChatCopy(ctx context.Context, c *entity.ChatCopy) error
+	GetChatCopies(ctx context.Context, messageID int64) ([]*entity.ChatCopy, error)
+	GetChatMessageByCopy(ctx context.Context, chatID int64, telegramMessageID int) (*entity.ChatMessage, error)
+	SetChatMuted(ctx context.Context, matchID, userID int64, muted bool) error
+	IsChatMuted(ctx context.Context, matchID, userID int64) (bool, error)
+	LinkMatchChat(ctx context.Context, chat *entity.MatchChat) error
+	UnlinkMatchChat(ctx context.Context, matchID int64) ([]*entity.ChatInvite, error)
+	GetMatchChat(ctx context.Context, matchID int64) (*entity.MatchChat, error)
+	SaveChatInvite(ctx context.Context, invite *entity.ChatInvite) error
+	GetChatInvite(ctx context.Context, matchID, userID int64) (*entity.ChatInvite, error)
+	DeleteChatInvite(ctx context.Context, matchID, userID int64) error
+	KeepsMatchChat(ctx context.Context, chat *entity.MatchChat, userID int64) (bool, error)
+	GetNotificationSettings(ctx context.Context, userID int64) (*entity.NotificationSettings, error)
+	SaveNotificationSettings(ctx context.Context, settings *entity.NotificationSettings) error
+	HoldNotification(ctx context.Context, n *entity.PendingNotification) error
+	DueDigests(ctx context.Context, now time.Time) ([]*entity.Digest, error)
+	DeletePendingNotifications(ctx context.Context, ids []int64) error
+	CreateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error
+	UpdateOutboundMessage(ctx context.Context, m *entity.OutboundMessage) error
+	GetDueOutboundMessages(ctx context.Context, now time.Time, limit int) ([]*entity.OutboundMessage, error)
+	MarkUnreachable(ctx context.Context, chatID int64, reason enum.UnreachableReason) error
+	GetUnreachableUsers(ctx context.Context, userIDs []int64) ([]*entity.User, error)
 	CancelMatch(ctx context.Context, matchID int64) error
 	GetMatchesByUserID(ctx context.Context, userID int64) ([]*entity.Match, error)
 	GetMatchesByOrganizerID(ctx context.Context, userID int64) ([]*entity.Match, error)
@@ -29,11 +108,14 @@ type Service interface {
 
 type service struct {
 	matchesRepository matches.Repository
+	// badges are the badges teams can have, new matches take them in order.
+	badges []string
 }
 
-func New(matchesRepository matches.Repository) Service {
+func New(matchesRepository matches.Repository, badges []string) Service {
 	return &service{
 		matchesRepository: matchesRepository,
+		badges:            badges,
 	}
 }
 
@@ -49,29 +131,53 @@ func (s *service) CancelMatch(ctx context.Context, matchID int64) error {
 	return s.matchesRepository.CancelMatch(ctx, matchID)
 }
 
-func (s *service) SignOutMatch(ctx context.Context, userID, matchID int64) error {
-	return s.matchesRepository.DeleteTeamMember(ctx, userID, matchID)
-}
-
-func (s *service) SignUpToMatch(ctx context.Context, userID, teamID int64) error {
-	return s.matchesRepository.SignUpToMatch(ctx, userID, teamID)
-}
-
 func (s *service) SetMatchConfirmed(ctx context.Context, confirmed bool, memberID, matchID int64) error {
 	return s.matchesRepository.SetMatchConfirmed(ctx, confirmed, memberID, matchID)
 }
 
-func (s *service) SetMatchPaid(ctx context.Context, confirmed bool, memberID, matchID int64) error {
-	return s.matchesRepository.SetMatchPaid(ctx, confirmed, memberID, matchID)
+// SetMatchPaid marks the player's payment, recording the price they paid
+// at that moment: the price of a split rent changes as players come and go.
+func (s *service) SetMatchPaid(ctx context.Context, paid bool, memberID, matchID int64) error {
+	var amount int64
+	if paid {
+		match, err := s.GetMatchByMatchID(ctx, matchID)
+		if err != nil {
+			return err
+		}
+		amount = match.PriceFor(memberID)
+	}
+	return s.matchesRepository.SetMatchPaid(ctx, paid, amount, memberID, matchID)
 }
 
+// CreateMatch creates the match with its teams. Venues that block
+// overlapping bookings make it fail with errors.Conflict, the check being
+// made by the repository together with the insert.
 func (s *service) CreateMatch(ctx context.Context, match *entity.Match) (*entity.Match, error) {
-	match.Teams = lo.Map(teams[:match.TeamCount], func(item string, _ int) *entity.Team {
+	match.Teams = lo.Times(int(match.TeamCount), func(i int) *entity.Team {
 		return &entity.Team{
-			Name: item,
+			Emoji: s.badges[i%len(s.badges)],
+			Size:  match.TeamSize,
 		}
 	})
-	return s.matchesRepository.CreateMatch(ctx, match)
+	match, err := s.matchesRepository.CreateMatch(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+	if match.Sport, err = s.matchesRepository.GetSport(ctx, match.Type); err != nil {
+		return nil, err
+	}
+	if match.VenueID != nil {
+		venue, err := s.matchesRepository.GetVenue(ctx, *match.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		if venue.OwnedBy(match.OrganizerID) {
+			if err := s.matchesRepository.SetVenueDefaultRent(ctx, venue.ID, match.Rent); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return match, nil
 }
 
 func (s *service) GetMatchIDByTeamID(ctx context.Context, id int64) (int64, error) {
@@ -79,14 +185,47 @@ func (s *service) GetMatchIDByTeamID(ctx context.Context, id int64) (int64, erro
 }
 
 func (s *service) GetOpenMatchesBySport(ctx context.Context, sport enum.SportType) ([]*entity.Match, error) {
-	return s.matchesRepository.GetOpenMatchesBySport(ctx, sport)
+	return s.matchesRepository.SearchMatches(ctx, &entity.MatchFilter{Sport: sport})
+}
+
+func (s *service) SearchMatches(ctx context.Context, filter *entity.MatchFilter) ([]*entity.Match, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		filter.From, filter.To = filter.To, filter.From
+	}
+	return s.matchesRepository.SearchMatches(ctx, filter)
 }
 
 func (s *service) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
 	return s.matchesRepository.GetUserByUsername(ctx, username)
 }
-func (s *service) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
-	return s.matchesRepository.CreateUser(ctx, user)
+
+func (s *service) GetUserByID(ctx context.Context, id int64) (*entity.User, error) {
+	return s.matchesRepository.GetUserByID(ctx, id)
+}
+
+// SyncUser registers the Telegram user on first contact and refreshes the
+// stored name, username and chat on every following update. A new user or
+// one with a new username is added to the teams they were invited to by
+// that username, the claimed invitations are returned.
+func (s *service) SyncUser(ctx context.Context, user *entity.User) (*entity.User, []*entity.Invitation, error) {
+	user, renamed, err := s.matchesRepository.UpsertUser(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !renamed || user.Username == "" {
+		return user, nil, nil
+	}
+	invitations, err := s.matchesRepository.ClaimInvitations(ctx, user.ID, user.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, invitations, nil
+}
+
+// SetLanguage makes the bot talk to the user in the language regardless of
+// the language of their Telegram app.
+func (s *service) SetLanguage(ctx context.Context, userID int64, lang i18n.Lang) error {
+	return s.matchesRepository.SetUserLanguage(ctx, userID, string(lang))
 }
 
 func (s *service) GetMatchByMatchID(ctx context.Context, id int64) (*entity.Match, error) {
@@ -98,7 +237,19 @@ func (s *service) GetMatchByMatchID(ctx context.Context, id int64) (*entity.Matc
 	if err != nil {
 		return nil, err
 	}
-	match.OrganizerUsername = user.Username
+	match.OrganizerName = user.DisplayName()
+	sport, err := s.matchesRepository.GetSport(ctx, match.Type)
+	if err != nil {
+		return nil, err
+	}
+	match.Sport = sport
+	if match.VenueID != nil {
+		venue, err := s.matchesRepository.GetVenue(ctx, *match.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		match.Venue = venue
+	}
 	teams, err := s.matchesRepository.GetTeamsByMatchID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -111,35 +262,85 @@ func (s *service) GetMatchByMatchID(ctx context.Context, id int64) (*entity.Matc
 		team.Members = members
 	}
 	match.Teams = teams
+	discounts, err := s.matchesRepository.GetMatchDiscounts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	match.Discounts = make(map[int64]int64, len(discounts))
+	for _, discount := range discounts {
+		match.Discounts[discount.UserID] = discount.Percent
+	}
 	return match, nil
 }
 
-func (s *service) AddTeamMembers(ctx context.Context, teamID int64, members []string) error {
-	var users []*entity.User
-	for _, member := range members {
-		user, err := s.matchesRepository.GetUserByUsername(ctx, member[1:])
-		if err != nil {
+// AddTeamMembers adds the registered invitees to the team right away while
+// it has free places. Those who have not started the bot yet get a pending
+// invitation by username, and invitees that cannot be reached at all are
+// reported as invalid.
+func (s *service) AddTeamMembers(ctx context.Context, teamID, invitedBy int64, invitees []*entity.Invitee) (*entity.InviteResult, error) {
+	matchID, err := s.matchesRepository.GetMatchIDByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	team := match.Team(teamID)
+	room := team.Size - int64(len(team.Members))
+	result := &entity.InviteResult{}
+	for _, invitee := range invitees {
+		user, err := s.resolveInvitee(ctx, invitee)
+		if err == nil {
+			switch {
+			case match.TeamOf(user.ID) != nil || lo.ContainsBy(result.Added, func(item *entity.User) bool { return item.ID == user.ID }):
+				result.AlreadyIn = append(result.AlreadyIn, user.DisplayName())
+			case int64(len(result.Added)) >= room:
+				result.Full = append(result.Full, user.DisplayName())
+			default:
+				result.Added = append(result.Added, user)
+			}
 			continue
 		}
-		users = append(users, user)
+		username := strings.TrimPrefix(invitee.Username, "@")
+		if !usernameRegexp.MatchString(username) {
+			result.Invalid = append(result.Invalid, inviteeName(invitee))
+			continue
+		}
+		if err := s.matchesRepository.CreateInvitation(ctx, teamID, username, invitedBy); err != nil {
+			return nil, err
+		}
+		result.Pending = append(result.Pending, "@"+username)
 	}
-	userIDs := lo.Map(users, func(item *entity.User, _ int) int64 {
+	result.AlreadyIn = lo.Uniq(result.AlreadyIn)
+	result.Pending = lo.Uniq(result.Pending)
+	userIDs := lo.Map(result.Added, func(item *entity.User, _ int) int64 {
 		return item.ID
 	})
-	return s.matchesRepository.AddTeamMembers(ctx, teamID, userIDs)
+	if err := s.matchesRepository.AddTeamMembers(ctx, teamID, userIDs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
+func (s *service) resolveInvitee(ctx context.Context, invitee *entity.Invitee) (*entity.User, error) {
+	if invitee.TelegramID != 0 {
+		return s.matchesRepository.GetUserByTelegramID(ctx, invitee.TelegramID)
+	}
+	return s.matchesRepository.GetUserByUsername(ctx, strings.TrimPrefix(invitee.Username, "@"))
 }
 
-func (s *service) GetUsersByUsernames(ctx context.Context, members []string) []*entity.User {
-	var users []*entity.User
-	for _, member := range members {
-		user, err := s.matchesRepository.GetUserByUsername(ctx, member[1:])
-		if err != nil {
-			continue
-		}
-		users = append(users, user)
+func inviteeName(invitee *entity.Invitee) string {
+	switch {
+	case invitee.Username != "":
+		return invitee.Username
+	case invitee.Name != "":
+		return invitee.Name
+	default:
+		return strconv.FormatInt(invitee.TelegramID, 10)
 	}
-	return users
 }
 
-var teams = []string{"red", "blue", "green", "yellow", "purple", "black", "brown"}
+// usernameRegexp matches Telegram usernames: 5-32 characters, latin
+// letters, digits and underscores, starting with a letter.
+var usernameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
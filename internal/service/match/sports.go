@@ -0,0 +1,48 @@
+package match
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/i18n"
+)
+
+// sportCodePattern keeps codes usable in callback data, which is split
+// on "-".
+var sportCodePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+func (s *service) GetSports(ctx context.Context) ([]*entity.Sport, error) {
+	return s.matchesRepository.GetSports(ctx)
+}
+
+func (s *service) GetSport(ctx context.Context, code enum.SportType) (*entity.Sport, error) {
+	return s.matchesRepository.GetSport(ctx, code)
+}
+
+// SaveSport adds a sport or updates the defaults of an existing one.
+func (s *service) SaveSport(ctx context.Context, sport *entity.Sport) error {
+	if !sportCodePattern.MatchString(string(sport.Code)) {
+		return errors.Invalid.Newf("sport code must be lowercase latin letters, digits or \"_\", got %q", sport.Code)
+	}
+	if sport.Names[string(i18n.Default)] == "" {
+		return errors.Invalid.Newf("sport %s has no name in %s", sport.Code, i18n.Default)
+	}
+	if len(sport.TeamSizes) == 0 {
+		return errors.Invalid.Newf("sport %s has no team sizes", sport.Code)
+	}
+	for _, size := range sport.TeamSizes {
+		if size < 1 {
+			return errors.Invalid.Newf("team size must be positive, got %d", size)
+		}
+	}
+	if sport.DurationMinutes < 15 {
+		return errors.Invalid.Newf("duration must be at least 15 minutes, got %d", sport.DurationMinutes)
+	}
+	if sport.TeamCount < 2 {
+		return errors.Invalid.Newf("a match needs at least 2 teams, got %d", sport.TeamCount)
+	}
+	return s.matchesRepository.SaveSport(ctx, sport)
+}
@@ -0,0 +1,32 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+// MoveToTeam moves a player of the match to another of its teams if that
+// team has a free place.
+func (s *service) MoveToTeam(ctx context.Context, matchID, userID, teamID int64) (*entity.Match, error) {
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	from, to := match.TeamOf(userID), match.Team(teamID)
+	if from == nil || to == nil {
+		return nil, errors.Invalid.Newf("cannot move user %d to team %d of match %d", userID, teamID, matchID)
+	}
+	if from.ID == to.ID {
+		return match, nil
+	}
+	if !to.HasRoom() {
+		return nil, errors.Conflict.Newf("team %d is full", teamID)
+	}
+	move := &entity.TeamMove{User: &entity.User{ID: userID}, FromTeamID: from.ID, ToTeamID: to.ID}
+	if err := s.matchesRepository.MoveTeamMembers(ctx, []*entity.TeamMove{move}); err != nil {
+		return nil, err
+	}
+	return s.GetMatchByMatchID(ctx, matchID)
+}
@@ -0,0 +1,79 @@
+package match
+
+import (
+	"context"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+)
+
+// OfferSpot offers the player's spot to someone who has started the bot.
+// The spot changes hands only when the offer is accepted, and leaving this
+// way is never penalized.
+func (s *service) OfferSpot(ctx context.Context, matchID, userID int64, invitee *entity.Invitee) (*entity.SpotTransfer, *entity.User, error) {
+	receiver, err := s.resolveInvitee(ctx, invitee)
+	if err != nil {
+		return nil, nil, errors.Invalid.Wrapf(err, "user %s not found", inviteeName(invitee))
+	}
+	match, err := s.GetMatchByMatchID(ctx, matchID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if match.TeamOf(userID) == nil {
+		return nil, nil, errors.Forbidden.Newf("user %d does not play in match %d", userID, matchID)
+	}
+	if match.TeamOf(receiver.ID) != nil {
+		return nil, nil, errors.Conflict.Newf("user %d already plays in match %d", receiver.ID, matchID)
+	}
+	transfer, err := s.matchesRepository.CreateSpotTransfer(ctx, &entity.SpotTransfer{
+		MatchID:    matchID,
+		FromUserID: userID,
+		ToUserID:   receiver.ID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return transfer, receiver, nil
+}
+
+func (s *service) GetSpotTransfer(ctx context.Context, id int64) (*entity.SpotTransfer, error) {
+	return s.matchesRepository.GetSpotTransfer(ctx, id)
+}
+
+// AcceptSpotTransfer gives the spot to the receiver, who must be allowed to
+// sign up for the match on their own: a spot can't be handed to a player
+// the match's reliability threshold or eligibility rules keep out.
+func (s *service) AcceptSpotTransfer(ctx context.Context, id, userID int64) (*entity.SpotTransfer, error) {
+	transfer, err := s.transferTo(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	match, err := s.GetMatchByMatchID(ctx, transfer.MatchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureCanJoin(ctx, match, userID); err != nil {
+		return nil, err
+	}
+	return s.matchesRepository.AcceptSpotTransfer(ctx, id)
+}
+
+func (s *service) DeclineSpotTransfer(ctx context.Context, id, userID int64) (*entity.SpotTransfer, error) {
+	if _, err := s.transferTo(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.matchesRepository.DeclineSpotTransfer(ctx, id)
+}
+
+// transferTo returns the spot transfer, failing with a Forbidden error when
+// it is addressed to someone else.
+func (s *service) transferTo(ctx context.Context, id, userID int64) (*entity.SpotTransfer, error) {
+	transfer, err := s.matchesRepository.GetSpotTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != userID {
+		return nil, errors.Forbidden.Newf("spot transfer %d is not addressed to user %d", id, userID)
+	}
+	return transfer, nil
+}
@@ -0,0 +1,74 @@
+package match
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/entity"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/domain/enum"
+	"github.com/DarkhanShakhan/telegram-bot-template/internal/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+)
+
+const venueSuggestionsLimit = 6
+
+func (s *service) GetVenue(ctx context.Context, id int64) (*entity.Venue, error) {
+	return s.matchesRepository.GetVenue(ctx, id)
+}
+
+func (s *service) GetVenuesBySport(ctx context.Context, sport enum.SportType) ([]*entity.Venue, error) {
+	return s.matchesRepository.GetVenuesBySport(ctx, string(sport), venueSuggestionsLimit)
+}
+
+func (s *service) GetVenuesByCreator(ctx context.Context, userID int64) ([]*entity.Venue, error) {
+	return s.matchesRepository.GetVenuesByCreator(ctx, userID)
+}
+
+// SaveVenue returns the venue with the same name if it is already known,
+// so that typing a pitch name twice does not create duplicates, and
+// creates it otherwise. The sport is remembered for future suggestions, the
+// location only when the venue is the organizer's own.
+func (s *service) SaveVenue(ctx context.Context, venue *entity.Venue, sport enum.SportType) (*entity.Venue, error) {
+	venue.Name = strings.TrimSpace(venue.Name)
+	existing, err := s.matchesRepository.GetVenueByName(ctx, venue.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		venue.Sports = []string{string(sport)}
+		return s.matchesRepository.CreateVenue(ctx, venue)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !lo.Contains(existing.Sports, string(sport)) {
+		if err := s.matchesRepository.AddVenueSport(ctx, existing.ID, string(sport)); err != nil {
+			return nil, err
+		}
+		existing.Sports = append(existing.Sports, string(sport))
+	}
+	if venue.HasLocation() && !existing.HasLocation() && venue.CreatedBy != nil && existing.OwnedBy(*venue.CreatedBy) {
+		if err := s.matchesRepository.SetVenueLocation(ctx, existing.ID, entity.GeoPoint{
+			Latitude:  *venue.Latitude,
+			Longitude: *venue.Longitude,
+		}, venue.Address); err != nil {
+			return nil, err
+		}
+		existing.Latitude, existing.Longitude = venue.Latitude, venue.Longitude
+	}
+	return existing, nil
+}
+
+func (s *service) SetVenueLocation(ctx context.Context, id int64, point entity.GeoPoint, address string) error {
+	return s.matchesRepository.SetVenueLocation(ctx, id, point, address)
+}
+
+func (s *service) SetVenuePhoto(ctx context.Context, id int64, fileID string) error {
+	return s.matchesRepository.SetVenuePhoto(ctx, id, fileID)
+}
+
+// GetMatchesNear returns open matches at venues within radiusKm of the point,
+// closest first.
+func (s *service) GetMatchesNear(ctx context.Context, filter *entity.MatchFilter, point entity.GeoPoint, radiusKm float64) ([]*entity.Match, error) {
+	filter.Near = &point
+	filter.RadiusKm = radiusKm
+	return s.SearchMatches(ctx, filter)
+}
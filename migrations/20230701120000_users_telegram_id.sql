@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS telegram_id BIGINT;
+UPDATE users SET telegram_id = chat_id WHERE telegram_id IS NULL;
+ALTER TABLE users ALTER COLUMN telegram_id SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_telegram_id_key UNIQUE (telegram_id);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users ALTER COLUMN username SET DEFAULT '';
+CREATE INDEX IF NOT EXISTS users_username_idx ON users (lower(username));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_username_idx;
+ALTER TABLE users ALTER COLUMN username DROP DEFAULT;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_telegram_id_key;
+ALTER TABLE users DROP COLUMN IF EXISTS telegram_id;
+-- +goose StatementEnd
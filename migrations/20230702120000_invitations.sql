@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invitations (
+    id SERIAL PRIMARY KEY,
+    team_id INT NOT NULL,
+    username TEXT NOT NULL,
+    invited_by INT NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_team FOREIGN KEY(team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    CONSTRAINT fk_inviter FOREIGN KEY(invited_by) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS invitations_team_username_idx ON invitations (team_id, lower(username));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS invitations;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS venues (
+    id SERIAL PRIMARY KEY,
+    "name" TEXT NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    sports TEXT[] NOT NULL DEFAULT '{}',
+    default_rent INT NOT NULL DEFAULT 0,
+    photo_file_id TEXT NOT NULL DEFAULT '',
+    created_by INT,
+    CONSTRAINT fk_creator FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS venues_name_idx ON venues (lower("name"));
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS venue_id INT REFERENCES venues(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE matches DROP COLUMN IF EXISTS venue_id;
+DROP TABLE IF EXISTS venues;
+-- +goose StatementEnd
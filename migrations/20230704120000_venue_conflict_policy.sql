@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE venues ADD COLUMN IF NOT EXISTS conflict_policy TEXT NOT NULL DEFAULT 'warn';
+CREATE INDEX IF NOT EXISTS matches_venue_time_idx ON matches (venue_id, start_at, finish_at) WHERE cancelled = false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS matches_venue_time_idx;
+ALTER TABLE venues DROP COLUMN IF EXISTS conflict_policy;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS player_ratings (
+    user_id INT NOT NULL,
+    sport TEXT NOT NULL,
+    rating INT NOT NULL DEFAULT 1000,
+    PRIMARY KEY (user_id, sport),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS player_ratings;
+-- +goose StatementEnd
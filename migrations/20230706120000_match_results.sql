@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS results_requested BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS team_scores (
+    team_id INT PRIMARY KEY,
+    score INT NOT NULL,
+    CONSTRAINT fk_team FOREIGN KEY(team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS player_match_stats (
+    match_id INT NOT NULL,
+    user_id INT NOT NULL,
+    goals INT NOT NULL DEFAULT 0,
+    mvp BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (match_id, user_id),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS player_match_stats;
+DROP TABLE IF EXISTS team_scores;
+ALTER TABLE matches DROP COLUMN IF EXISTS results_requested;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team_members ADD COLUMN IF NOT EXISTS attended BOOLEAN;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS min_reliability INT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS cancellations (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL,
+    user_id INT NOT NULL,
+    late BOOLEAN NOT NULL DEFAULT false,
+    cancelled_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS cancellations_user_idx ON cancellations(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS cancellations;
+ALTER TABLE matches DROP COLUMN IF EXISTS min_reliability;
+ALTER TABLE team_members DROP COLUMN IF EXISTS attended;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS cancel_window_hours INT NOT NULL DEFAULT 0;
+ALTER TABLE cancellations ADD COLUMN IF NOT EXISTS liable_amount INT NOT NULL DEFAULT 0;
+ALTER TABLE cancellations ADD COLUMN IF NOT EXISTS replaced_by INT REFERENCES users(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cancellations DROP COLUMN IF EXISTS replaced_by;
+ALTER TABLE cancellations DROP COLUMN IF EXISTS liable_amount;
+ALTER TABLE matches DROP COLUMN IF EXISTS cancel_window_hours;
+-- +goose StatementEnd
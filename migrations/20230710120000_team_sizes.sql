@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- teams used to be created without a size
+UPDATE teams SET size = m.team_size FROM matches m WHERE m.id = teams.match_id AND teams.size = 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS clubs (
+    id SERIAL PRIMARY KEY,
+    owner_id INT NOT NULL,
+    "name" TEXT NOT NULL,
+    emoji TEXT NOT NULL DEFAULT '',
+    CONSTRAINT fk_owner FOREIGN KEY(owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS clubs_owner_name_idx ON clubs(owner_id, lower("name"));
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS emoji TEXT NOT NULL DEFAULT '';
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS club_id INT REFERENCES clubs(id) ON DELETE SET NULL;
+-- teams used to be named after their color
+UPDATE teams SET emoji = CASE "name"
+        WHEN 'red' THEN '🟥'
+        WHEN 'blue' THEN '🟦'
+        WHEN 'green' THEN '🟩'
+        WHEN 'yellow' THEN '🟨'
+        WHEN 'purple' THEN '🟪'
+        WHEN 'black' THEN '⬛️'
+        WHEN 'brown' THEN '🟫'
+    END, "name" = ''
+    WHERE "name" IN ('red', 'blue', 'green', 'yellow', 'purple', 'black', 'brown');
+UPDATE teams SET "name" = '' WHERE "name" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE teams SET "name" = CASE emoji
+        WHEN '🟥' THEN 'red'
+        WHEN '🟦' THEN 'blue'
+        WHEN '🟩' THEN 'green'
+        WHEN '🟨' THEN 'yellow'
+        WHEN '🟪' THEN 'purple'
+        WHEN '⬛️' THEN 'black'
+        WHEN '🟫' THEN 'brown'
+    END
+    WHERE "name" = '';
+ALTER TABLE teams DROP COLUMN IF EXISTS club_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS emoji;
+DROP TABLE IF EXISTS clubs;
+-- +goose StatementEnd
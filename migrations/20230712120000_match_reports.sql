@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_reports (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL,
+    author_id INT NOT NULL,
+    "text" TEXT NOT NULL DEFAULT '',
+    published BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_author FOREIGN KEY(author_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- an author has at most one report being composed per match
+CREATE UNIQUE INDEX IF NOT EXISTS match_reports_draft_idx ON match_reports(match_id, author_id) WHERE published = false;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS report_attachments (
+    id SERIAL PRIMARY KEY,
+    report_id INT NOT NULL,
+    kind TEXT NOT NULL,
+    file_id TEXT NOT NULL,
+    CONSTRAINT fk_report FOREIGN KEY(report_id) REFERENCES match_reports(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS report_attachments;
+DROP TABLE IF EXISTS match_reports;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS pricing_model TEXT NOT NULL DEFAULT 'capacity';
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS fixed_price INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_discounts (
+    match_id INT NOT NULL,
+    user_id INT NOT NULL,
+    "percent" INT NOT NULL CHECK ("percent" BETWEEN 0 AND 100),
+    PRIMARY KEY (match_id, user_id),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS match_discounts;
+ALTER TABLE matches DROP COLUMN IF EXISTS fixed_price;
+ALTER TABLE matches DROP COLUMN IF EXISTS pricing_model;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS sports (
+    code TEXT PRIMARY KEY,
+    emoji TEXT NOT NULL DEFAULT '',
+    names JSONB NOT NULL DEFAULT '{}',
+    team_sizes INT[] NOT NULL DEFAULT '{}',
+    duration_minutes INT NOT NULL DEFAULT 90,
+    team_count INT NOT NULL DEFAULT 2,
+    position INT NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO sports(code, emoji, names, team_sizes, duration_minutes, team_count, position) VALUES
+    ('football', '⚽️', '{"ru": "Футбол", "kk": "Футбол", "en": "Football"}', '{4,5,6,7,8,9}', 90, 2, 1),
+    ('volleyball', '🏐', '{"ru": "Волейбол", "kk": "Волейбол", "en": "Volleyball"}', '{4,5,6}', 120, 2, 2),
+    ('basketball', '🏀', '{"ru": "Баскетбол", "kk": "Баскетбол", "en": "Basketball"}', '{3,4,5}', 90, 2, 3)
+ON CONFLICT (code) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE matches ALTER COLUMN sport TYPE TEXT USING sport::TEXT;
+ALTER TABLE matches ADD CONSTRAINT fk_sport FOREIGN KEY(sport) REFERENCES sports(code);
+DROP TYPE IF EXISTS sport_type;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TYPE sport_type AS ENUM('football', 'volleyball', 'basketball');
+ALTER TABLE matches DROP CONSTRAINT IF EXISTS fk_sport;
+ALTER TABLE matches ALTER COLUMN sport TYPE sport_type USING sport::sport_type;
+DROP TABLE IF EXISTS sports;
+-- +goose StatementEnd
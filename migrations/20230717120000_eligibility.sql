@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS gender TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS birth_year INT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS min_level INT;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS max_level INT;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS gender TEXT NOT NULL DEFAULT '';
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS min_age INT;
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS max_age INT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE matches DROP COLUMN IF EXISTS max_age;
+ALTER TABLE matches DROP COLUMN IF EXISTS min_age;
+ALTER TABLE matches DROP COLUMN IF EXISTS gender;
+ALTER TABLE matches DROP COLUMN IF EXISTS max_level;
+ALTER TABLE matches DROP COLUMN IF EXISTS min_level;
+ALTER TABLE users DROP COLUMN IF EXISTS birth_year;
+ALTER TABLE users DROP COLUMN IF EXISTS gender;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_messages (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL,
+    sender_id INT NOT NULL,
+    reply_to_id INT,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sender FOREIGN KEY(sender_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reply_to FOREIGN KEY(reply_to_id) REFERENCES match_messages(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS match_messages_sender_idx ON match_messages (sender_id, created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_message_copies (
+    message_id INT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    telegram_message_id INT NOT NULL,
+    PRIMARY KEY (message_id, chat_id),
+    CONSTRAINT fk_message FOREIGN KEY(message_id) REFERENCES match_messages(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS match_message_copies_telegram_idx ON match_message_copies (chat_id, telegram_message_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_chat_mutes (
+    match_id INT NOT NULL,
+    user_id INT NOT NULL,
+    PRIMARY KEY (match_id, user_id),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS match_chat_mutes;
+DROP TABLE IF EXISTS match_message_copies;
+DROP TABLE IF EXISTS match_messages;
+-- +goose StatementEnd
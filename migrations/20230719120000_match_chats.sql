@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_chats (
+    match_id INT PRIMARY KEY,
+    chat_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    linked_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS match_chats_chat_idx ON match_chats (chat_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS match_chat_invites (
+    match_id INT NOT NULL,
+    user_id INT NOT NULL,
+    invite_link TEXT NOT NULL,
+    PRIMARY KEY (match_id, user_id),
+    CONSTRAINT fk_match FOREIGN KEY(match_id) REFERENCES matches(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS match_chat_invites;
+DROP TABLE IF EXISTS match_chats;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id INT PRIMARY KEY,
+    muted TEXT[] NOT NULL DEFAULT '{}',
+    digest BOOLEAN NOT NULL DEFAULT false,
+    quiet_from INT,
+    quiet_to INT,
+    utc_offset INT NOT NULL DEFAULT 5,
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS pending_notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    kind TEXT NOT NULL,
+    text TEXT NOT NULL,
+    parse_mode TEXT NOT NULL DEFAULT '',
+    reply_markup JSONB,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS pending_notifications_user_idx ON pending_notifications (user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pending_notifications;
+DROP TABLE IF EXISTS notification_settings;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ALTER COLUMN chat_id TYPE BIGINT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS unreachable TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS unreachable_since TIMESTAMP WITHOUT TIME ZONE;
+CREATE INDEX IF NOT EXISTS users_chat_id_idx ON users (chat_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_chat_id_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS unreachable_since;
+ALTER TABLE users DROP COLUMN IF EXISTS unreachable;
+ALTER TABLE users ALTER COLUMN chat_id TYPE INT;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team_members ADD COLUMN IF NOT EXISTS paid_amount INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_members DROP COLUMN IF EXISTS paid_amount;
+-- +goose StatementEnd